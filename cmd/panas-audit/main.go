@@ -0,0 +1,96 @@
+// Command panas-audit reports the everyday words whose phonetic code collides with the code of a PANAS-t state,
+// ranked by frequency, and can write them as an exclusion list for `sentiment.Analyzer.Exclusions`.
+//
+// Usage:
+//
+//	panas-audit [-encoder soundex] [-lexicon panas-t] [-words words.txt] [-top 10] [-max-rank 0] [-exclusions exclusions.json]
+//
+// The word list has a word per line, optionally followed by its count; a list without counts is ranked by its order.
+// The bundled list, used by default, has the counts of the words of a public-domain English text (Newton's Opticks);
+// to audit a corpus of posts, supply a list counted on that corpus.
+//
+// The states are matched with the "soundex" encoder, the default. The other encoders report the collisions
+// they would cause, to compare the encoders; they cannot write an exclusion list, as their collisions
+// are not the ones of matching.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/coderafting/panas-go/pkg/sentiment"
+)
+
+func main() {
+	encoderName := flag.String("encoder", "soundex", "phonetic encoder, one of "+strings.Join(sentiment.EncoderNames(), ", ")+
+		"; matching uses soundex, the others are only audited")
+	lexiconName := flag.String("lexicon", "panas-t", "lexicon, one of "+strings.Join(sentiment.LexiconNames(), ", "))
+	wordsPath := flag.String("words", "", "word-frequency list (default: the bundled list)")
+	top := flag.Int("top", 10, "maximum number of colliding words reported per state, 0 for all")
+	maxRank := flag.Int("max-rank", 0, "only consider the words ranked up to this rank, 0 for all")
+	exclusionsPath := flag.String("exclusions", "", "write the colliding words as a JSON exclusion list to this file")
+	flag.Parse()
+
+	if err := run(*encoderName, *lexiconName, *wordsPath, *top, *maxRank, *exclusionsPath); err != nil {
+		fmt.Fprintln(os.Stderr, "panas-audit:", err)
+		os.Exit(1)
+	}
+}
+
+func run(encoderName, lexiconName, wordsPath string, top, maxRank int, exclusionsPath string) error {
+	encoder, err := sentiment.EncoderByName(encoderName)
+	if err != nil {
+		return err
+	}
+	if exclusionsPath != "" && encoder != sentiment.DefaultEncoder {
+		return fmt.Errorf("exclusions require the matching encoder, soundex, not %s", encoderName)
+	}
+	lexicon, err := sentiment.LexiconByName(lexiconName)
+	if err != nil {
+		return err
+	}
+	words := sentiment.BundledWordList()
+	if wordsPath != "" {
+		f, err := os.Open(wordsPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if words, err = sentiment.ParseWordList(f); err != nil {
+			return fmt.Errorf("%s: %v", wordsPath, err)
+		}
+	}
+	if maxRank > 0 && maxRank < len(words) {
+		words = words[:maxRank]
+	}
+
+	audits := sentiment.Audit(encoder, lexicon.States, words)
+	for _, a := range audits {
+		fmt.Printf("%s (%d)\n", a.State, len(a.Collisions))
+		for i, c := range a.Collisions {
+			if top > 0 && i == top {
+				break
+			}
+			if c.Count > 0 {
+				fmt.Printf("\t%d\t%s\t%s\t%d\n", c.Rank, c.Word, c.Code, c.Count)
+			} else {
+				fmt.Printf("\t%d\t%s\t%s\n", c.Rank, c.Word, c.Code)
+			}
+		}
+	}
+
+	if exclusionsPath == "" {
+		return nil
+	}
+	f, err := os.Create(exclusionsPath)
+	if err != nil {
+		return err
+	}
+	if err := sentiment.WriteExclusions(f, sentiment.Exclusions(audits, 0)); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
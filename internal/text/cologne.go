@@ -0,0 +1,90 @@
+package text
+
+/*
+Implementation of the Cologne phonetics (Kölner Phonetik) algorithm: https://en.wikipedia.org/wiki/Cologne_phonetics
+It is designed for German words, and codes the umlauts as vowels and the ß as an S.
+*/
+
+// Cologne is the Cologne phonetics encoder.
+type Cologne struct{}
+
+// Encode returns the Cologne phonetics code of the word.
+func (Cologne) Encode(s string) string {
+	w := upperLetters(s)
+	if len(w) == 0 {
+		return ""
+	}
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	in := func(c byte, set string) bool {
+		for i := 0; i < len(set); i++ {
+			if set[i] == c {
+				return true
+			}
+		}
+		return false
+	}
+	digits := make([]byte, 0, len(w)+1)
+	for i, c := range w {
+		prev, next := at(i-1), at(i+1)
+		switch {
+		case in(c, "AEIJOUY"):
+			digits = append(digits, '0')
+		case c == 'H':
+			// not coded
+		case c == 'B':
+			digits = append(digits, '1')
+		case c == 'P':
+			if next == 'H' {
+				digits = append(digits, '3')
+			} else {
+				digits = append(digits, '1')
+			}
+		case c == 'D' || c == 'T':
+			if in(next, "CSZ") {
+				digits = append(digits, '8')
+			} else {
+				digits = append(digits, '2')
+			}
+		case in(c, "FVW"):
+			digits = append(digits, '3')
+		case in(c, "GKQ"):
+			digits = append(digits, '4')
+		case c == 'C':
+			if i == 0 && in(next, "AHKLOQRUX") || i > 0 && in(next, "AHKOQUX") && !in(prev, "SZ") {
+				digits = append(digits, '4')
+			} else {
+				digits = append(digits, '8')
+			}
+		case c == 'X':
+			if in(prev, "CKQ") {
+				digits = append(digits, '8')
+			} else {
+				digits = append(digits, '4', '8')
+			}
+		case c == 'L':
+			digits = append(digits, '5')
+		case c == 'M' || c == 'N':
+			digits = append(digits, '6')
+		case c == 'R':
+			digits = append(digits, '7')
+		case c == 'S' || c == 'Z':
+			digits = append(digits, '8')
+		}
+	}
+	res := make([]byte, 0, len(digits))
+	for i, d := range digits {
+		if i > 0 && d == digits[i-1] {
+			continue
+		}
+		if d == '0' && i > 0 {
+			continue
+		}
+		res = append(res, d)
+	}
+	return string(res)
+}
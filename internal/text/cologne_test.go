@@ -0,0 +1,25 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestCologne(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected string
+	}
+	cases := []testCase{
+		{st: "Wikipedia", expected: "3412"},
+		{st: "Müller-Lüdenscheidt", expected: "65752682"},
+		{st: "Breschnew", expected: "17863"},
+		{st: "Meyer", expected: "67"},
+		{st: "Maier", expected: "67"},
+		{st: "", expected: ""}}
+	for _, c := range cases {
+		out := Cologne{}.Encode(c.st)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+		}
+	}
+}
@@ -0,0 +1,183 @@
+package text
+
+/*
+Implementation of the Daitch–Mokotoff Soundex algorithm: https://en.wikipedia.org/wiki/Daitch–Mokotoff_Soundex
+Some letters and letter groups have two possible pronunciations, in which case the word branches into
+several 6-digit codes, such as "Peters" (739400, 734000).
+*/
+
+import (
+	"sort"
+)
+
+// dmRule is the coding of a letter group: at the start of a word, before a vowel, and in any other position.
+// Each position lists the alternative codes, an empty code meaning that the group is not coded.
+type dmRule struct {
+	pattern             string
+	start, vowel, other []string
+}
+
+func dmCodes(codes ...string) []string { return codes }
+
+// dmRules are the letter groups of Daitch–Mokotoff, sorted by decreasing length when initialized.
+var dmRules = []dmRule{
+	{"AI", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"AJ", dmCodes("0"), dmCodes("1"), dmCodes("")},
+	{"AY", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"AU", dmCodes("0"), dmCodes("7"), dmCodes("")},
+	{"A", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"B", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"CHS", dmCodes("5"), dmCodes("54"), dmCodes("54")},
+	{"CH", dmCodes("5", "4"), dmCodes("5", "4"), dmCodes("5", "4")},
+	{"CK", dmCodes("5", "45"), dmCodes("5", "45"), dmCodes("5", "45")},
+	{"CZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"CS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"CSZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"CZS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"C", dmCodes("5", "4"), dmCodes("5", "4"), dmCodes("5", "4")},
+	{"DRZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"DRS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"DS", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"DSH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"DSZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"DZ", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"DZH", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"DZS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"D", dmCodes("3"), dmCodes("3"), dmCodes("3")}, {"DT", dmCodes("3"), dmCodes("3"), dmCodes("3")},
+	{"EI", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"EJ", dmCodes("0"), dmCodes("1"), dmCodes("")},
+	{"EY", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"EU", dmCodes("1"), dmCodes("1"), dmCodes("")},
+	{"E", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"FB", dmCodes("7"), dmCodes("7"), dmCodes("7")}, {"F", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"G", dmCodes("5"), dmCodes("5"), dmCodes("5")},
+	{"H", dmCodes("5"), dmCodes("5"), dmCodes("")},
+	{"IA", dmCodes("1"), dmCodes(""), dmCodes("")}, {"IE", dmCodes("1"), dmCodes(""), dmCodes("")},
+	{"IO", dmCodes("1"), dmCodes(""), dmCodes("")}, {"IU", dmCodes("1"), dmCodes(""), dmCodes("")},
+	{"I", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"J", dmCodes("1", "4"), dmCodes("", "4"), dmCodes("", "4")},
+	{"KS", dmCodes("5"), dmCodes("54"), dmCodes("54")}, {"KH", dmCodes("5"), dmCodes("5"), dmCodes("5")},
+	{"K", dmCodes("5"), dmCodes("5"), dmCodes("5")},
+	{"L", dmCodes("8"), dmCodes("8"), dmCodes("8")},
+	{"MN", dmCodes("66"), dmCodes("66"), dmCodes("66")}, {"M", dmCodes("6"), dmCodes("6"), dmCodes("6")},
+	{"NM", dmCodes("66"), dmCodes("66"), dmCodes("66")}, {"N", dmCodes("6"), dmCodes("6"), dmCodes("6")},
+	{"OI", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"OJ", dmCodes("0"), dmCodes("1"), dmCodes("")},
+	{"OY", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"O", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"P", dmCodes("7"), dmCodes("7"), dmCodes("7")}, {"PF", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"PH", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"Q", dmCodes("5"), dmCodes("5"), dmCodes("5")},
+	{"R", dmCodes("9"), dmCodes("9"), dmCodes("9")},
+	{"RZ", dmCodes("94", "4"), dmCodes("94", "4"), dmCodes("94", "4")},
+	{"RS", dmCodes("94", "4"), dmCodes("94", "4"), dmCodes("94", "4")},
+	{"SCHTSCH", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"SCHTSH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"SCHTCH", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"SCH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"SHTCH", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"SHCH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"SHTSH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"SHT", dmCodes("2"), dmCodes("43"), dmCodes("43")}, {"SCHT", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"SCHD", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"SH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"STCH", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"STSCH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"SC", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"STRZ", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"STRS", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"STSH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"ST", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"SZCZ", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"SZCS", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"SZT", dmCodes("2"), dmCodes("43"), dmCodes("43")}, {"SHD", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"SZD", dmCodes("2"), dmCodes("43"), dmCodes("43")}, {"SD", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"SZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"S", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TCH", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TTCH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TTSCH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TH", dmCodes("3"), dmCodes("3"), dmCodes("3")},
+	{"TRZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TRS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TSCH", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TSH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TS", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TTS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TTSZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TC", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TZ", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TTZ", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"TZS", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"TSZ", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"T", dmCodes("3"), dmCodes("3"), dmCodes("3")},
+	{"UI", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"UJ", dmCodes("0"), dmCodes("1"), dmCodes("")},
+	{"UY", dmCodes("0"), dmCodes("1"), dmCodes("")}, {"U", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"UE", dmCodes("0"), dmCodes(""), dmCodes("")},
+	{"V", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"W", dmCodes("7"), dmCodes("7"), dmCodes("7")},
+	{"X", dmCodes("5"), dmCodes("54"), dmCodes("54")},
+	{"Y", dmCodes("1"), dmCodes(""), dmCodes("")},
+	{"ZDZ", dmCodes("2"), dmCodes("4"), dmCodes("4")}, {"ZDZH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"ZHDZH", dmCodes("2"), dmCodes("4"), dmCodes("4")},
+	{"ZD", dmCodes("2"), dmCodes("43"), dmCodes("43")}, {"ZHD", dmCodes("2"), dmCodes("43"), dmCodes("43")},
+	{"ZH", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"ZS", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"ZSCH", dmCodes("4"), dmCodes("4"), dmCodes("4")}, {"ZSH", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+	{"Z", dmCodes("4"), dmCodes("4"), dmCodes("4")},
+}
+
+func init() {
+	sort.SliceStable(dmRules, func(i, j int) bool { return len(dmRules[i].pattern) > len(dmRules[j].pattern) })
+}
+
+// DaitchMokotoff is the Daitch–Mokotoff Soundex encoder.
+type DaitchMokotoff struct{}
+
+// Encode returns the first 6-digit Daitch–Mokotoff code of the word.
+func (e DaitchMokotoff) Encode(s string) string {
+	codes := e.EncodeAll(s)
+	if len(codes) == 0 {
+		return ""
+	}
+	return codes[0]
+}
+
+// dmBranch is one of the alternative codings of a word.
+type dmBranch struct {
+	code []byte
+	// last is the code of the previous letter group, which is not repeated.
+	last string
+}
+
+// EncodeAll returns the distinct 6-digit Daitch–Mokotoff codes of the word. The first code uses
+// the first alternative of every ambiguous letter group; the others are sorted.
+func (DaitchMokotoff) EncodeAll(s string) []string {
+	w := string(upperLetters(s))
+	if len(w) == 0 {
+		return []string{}
+	}
+	branches := []dmBranch{{}}
+	for i := 0; i < len(w); {
+		var rule *dmRule
+		for j, r := range dmRules {
+			if len(w)-i >= len(r.pattern) && w[i:i+len(r.pattern)] == r.pattern {
+				rule = &dmRules[j]
+				break
+			}
+		}
+		if rule == nil {
+			i++
+			continue
+		}
+		next := i + len(rule.pattern)
+		codes := rule.other
+		if i == 0 {
+			codes = rule.start
+		} else if next < len(w) && isVowel(w[next]) {
+			codes = rule.vowel
+		}
+		expanded := make([]dmBranch, 0, len(branches)*len(codes))
+		for _, b := range branches {
+			for _, c := range codes {
+				nb := dmBranch{code: append([]byte{}, b.code...), last: c}
+				if c != b.last {
+					nb.code = append(nb.code, c...)
+				}
+				expanded = append(expanded, nb)
+			}
+		}
+		branches = expanded
+		i = next
+	}
+	seen := map[string]bool{}
+	res := []string{}
+	for _, b := range branches {
+		code := b.code
+		for len(code) < 6 {
+			code = append(code, '0')
+		}
+		c := string(code[:6])
+		if !seen[c] {
+			seen[c] = true
+			res = append(res, c)
+		}
+	}
+	if len(res) > 1 {
+		sort.Strings(res[1:])
+	}
+	return res
+}
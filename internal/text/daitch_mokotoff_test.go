@@ -0,0 +1,30 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestDaitchMokotoff(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected []string
+	}
+	cases := []testCase{
+		{st: "Moskowitz", expected: []string{"645740"}},
+		{st: "Auerbach", expected: []string{"097500", "097400"}},
+		{st: "Peters", expected: []string{"739400", "734000"}},
+		{st: "Jackson", expected: []string{"154600", "145460", "445460", "454600"}},
+		{st: "", expected: []string{}}}
+	for _, c := range cases {
+		out := DaitchMokotoff{}.EncodeAll(c.st)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+}
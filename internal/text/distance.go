@@ -0,0 +1,61 @@
+package text
+
+/*
+Edit distance between words, to measure how far a phonetic match is from the word it matched.
+*/
+
+// Levenshtein returns the minimum number of single-byte insertions, deletions and substitutions
+// that turn a into b.
+func Levenshtein(a, b string) int {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	// short words, such as the ones of the lexicons, use a row on the stack
+	var buf [32]int
+	var row []int
+	if len(b) < len(buf) {
+		row = buf[:len(b)+1]
+	} else {
+		row = make([]int, len(b)+1)
+	}
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cur := row[j]
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min3(row[j]+1, row[j-1]+1, prev+cost)
+			prev = cur
+		}
+	}
+	return row[len(b)]
+}
+
+// Similarity returns 1 minus the Levenshtein distance of a and b relative to the length of the longer one,
+// from 0 for completely different words to 1 for equal ones.
+func Similarity(a, b string) float64 {
+	longer := len(a)
+	if len(b) > longer {
+		longer = len(b)
+	}
+	if longer == 0 {
+		return 1
+	}
+	return 1 - float64(Levenshtein(a, b))/float64(longer)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
@@ -0,0 +1,45 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	type testCase struct {
+		a        string
+		b        string
+		expected int
+	}
+	cases := []testCase{
+		{a: "", b: "", expected: 0},
+		{a: "", b: "sad", expected: 3},
+		{a: "happy", b: "happy", expected: 0},
+		{a: "hapy", b: "happy", expected: 1},
+		{a: "hippo", b: "happy", expected: 2},
+		{a: "kitten", b: "sitting", expected: 3},
+		{a: "seat", b: "sad", expected: 2}}
+
+	for _, c := range cases {
+		if out := Levenshtein(c.a, c.b); out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q and %q", c.expected, out, c.a, c.b)
+		}
+		if out := Levenshtein(c.b, c.a); out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q and %q", c.expected, out, c.b, c.a)
+		}
+	}
+}
+
+func TestLevenshteinAllocations(t *testing.T) {
+	if allocs := testing.AllocsPerRun(100, func() { Levenshtein("enthusiastc", "enthusiastic") }); allocs != 0 {
+		t.Errorf("Failed: expected 0 allocations, recieved %v", allocs)
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if out := Similarity("", ""); out != 1 {
+		t.Errorf("Failed: expected 1, recieved %v", out)
+	}
+	if out := Similarity("hippo", "happy"); out != 0.6 {
+		t.Errorf("Failed: expected 0.6, recieved %v", out)
+	}
+}
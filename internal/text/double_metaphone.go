@@ -0,0 +1,575 @@
+package text
+
+/*
+Implementation of the Double Metaphone algorithm by Lawrence Philips: https://en.wikipedia.org/wiki/Metaphone#Double_Metaphone
+The rules follow the original C++ implementation, which returns a primary code and a secondary code for the
+words whose pronunciation is ambiguous, such as "Smith" (SM0) and "Schmidt" (XMT, SMT).
+*/
+
+import (
+	"strings"
+)
+
+// DoubleMetaphone is the Double Metaphone encoder.
+type DoubleMetaphone struct {
+	// MaxLength is the maximum length of the codes. The zero value means 4.
+	MaxLength int
+}
+
+// Encode returns the primary Double Metaphone code of the word.
+func (e DoubleMetaphone) Encode(s string) string {
+	primary, _ := e.Codes(s)
+	return primary
+}
+
+// EncodeAll returns the primary and, when it differs, the secondary Double Metaphone code of the word.
+func (e DoubleMetaphone) EncodeAll(s string) []string {
+	primary, secondary := e.Codes(s)
+	if secondary == primary {
+		return []string{primary}
+	}
+	return []string{primary, secondary}
+}
+
+// Codes returns the primary and the secondary Double Metaphone codes of the word.
+func (e DoubleMetaphone) Codes(s string) (string, string) {
+	maxLength := e.MaxLength
+	if maxLength <= 0 {
+		maxLength = 4
+	}
+	letters := upperLetters(s)
+	if len(letters) == 0 {
+		return "", ""
+	}
+	dm := &doubleMetaphone{word: string(letters) + "     ", length: len(letters), last: len(letters) - 1}
+	dm.slavoGermanic = strings.ContainsAny(dm.word, "WK") || strings.Contains(dm.word, "CZ") || strings.Contains(dm.word, "WITZ")
+	dm.encode(maxLength)
+	primary, secondary := dm.primary.String(), dm.secondary.String()
+	if len(primary) > maxLength {
+		primary = primary[:maxLength]
+	}
+	if len(secondary) > maxLength {
+		secondary = secondary[:maxLength]
+	}
+	return primary, secondary
+}
+
+// doubleMetaphone is the state of the encoding of a single word.
+type doubleMetaphone struct {
+	// word is padded with spaces, so that the rules can look ahead without bound checks.
+	word               string
+	length, last       int
+	slavoGermanic      bool
+	primary, secondary strings.Builder
+}
+
+func (dm *doubleMetaphone) add(main string) {
+	dm.primary.WriteString(main)
+	dm.secondary.WriteString(main)
+}
+
+func (dm *doubleMetaphone) addAlt(main, alt string) {
+	dm.primary.WriteString(main)
+	dm.secondary.WriteString(alt)
+}
+
+func (dm *doubleMetaphone) at(i int) byte {
+	if i < 0 || i >= len(dm.word) {
+		return 0
+	}
+	return dm.word[i]
+}
+
+// stringAt checks if the word contains one of the candidates at the position.
+func (dm *doubleMetaphone) stringAt(start, length int, candidates ...string) bool {
+	if start < 0 || start+length > len(dm.word) {
+		return false
+	}
+	sub := dm.word[start : start+length]
+	for _, c := range candidates {
+		if sub == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (dm *doubleMetaphone) vowelAt(i int) bool {
+	c := dm.at(i)
+	return isVowel(c) || c == 'Y'
+}
+
+func (dm *doubleMetaphone) encode(maxLength int) {
+	current := 0
+	if dm.stringAt(0, 2, "GN", "KN", "PN", "WR", "PS") {
+		current++
+	}
+	if dm.at(0) == 'X' {
+		dm.add("S")
+		current++
+	}
+	for (dm.primary.Len() < maxLength || dm.secondary.Len() < maxLength) && current < dm.length {
+		switch dm.at(current) {
+		case 'A', 'E', 'I', 'O', 'U', 'Y':
+			if current == 0 {
+				dm.add("A")
+			}
+			current++
+		case 'B':
+			dm.add("P")
+			current += dm.skipDouble(current, 'B')
+		case 'C':
+			current = dm.encodeC(current)
+		case 'D':
+			switch {
+			case dm.stringAt(current, 2, "DG"):
+				if dm.stringAt(current+2, 1, "I", "E", "Y") {
+					dm.add("J")
+					current += 3
+				} else {
+					dm.add("TK")
+					current += 2
+				}
+			case dm.stringAt(current, 2, "DT", "DD"):
+				dm.add("T")
+				current += 2
+			default:
+				dm.add("T")
+				current++
+			}
+		case 'F':
+			dm.add("F")
+			current += dm.skipDouble(current, 'F')
+		case 'G':
+			current = dm.encodeG(current)
+		case 'H':
+			// only kept if first and before a vowel, or between two vowels
+			if (current == 0 || dm.vowelAt(current-1)) && dm.vowelAt(current+1) {
+				dm.add("H")
+				current += 2
+			} else {
+				current++
+			}
+		case 'J':
+			current = dm.encodeJ(current)
+		case 'K':
+			dm.add("K")
+			current += dm.skipDouble(current, 'K')
+		case 'L':
+			if dm.at(current+1) == 'L' {
+				// spanish, such as "cabrillo" and "gallegos"
+				if (current == dm.length-3 && dm.stringAt(current-1, 4, "ILLO", "ILLA", "ALLE")) ||
+					((dm.stringAt(dm.last-1, 2, "AS", "OS") || dm.stringAt(dm.last, 1, "A", "O")) && dm.stringAt(current-1, 4, "ALLE")) {
+					dm.addAlt("L", "")
+					current += 2
+					continue
+				}
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("L")
+		case 'M':
+			if (dm.stringAt(current-1, 3, "UMB") && (current+1 == dm.last || dm.stringAt(current+2, 2, "ER"))) || dm.at(current+1) == 'M' {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("M")
+		case 'N':
+			dm.add("N")
+			current += dm.skipDouble(current, 'N')
+		case 'P':
+			if dm.at(current+1) == 'H' {
+				dm.add("F")
+				current += 2
+				continue
+			}
+			// also accounts for "campbell" and "raspberry"
+			if dm.stringAt(current+1, 1, "P", "B") {
+				current += 2
+			} else {
+				current++
+			}
+			dm.add("P")
+		case 'Q':
+			dm.add("K")
+			current += dm.skipDouble(current, 'Q')
+		case 'R':
+			// french, such as "rogier", but not "hochmeier"
+			if current == dm.last && !dm.slavoGermanic && dm.stringAt(current-2, 2, "IE") && !dm.stringAt(current-4, 2, "ME", "MA") {
+				dm.addAlt("", "R")
+			} else {
+				dm.add("R")
+			}
+			current += dm.skipDouble(current, 'R')
+		case 'S':
+			current = dm.encodeS(current)
+		case 'T':
+			current = dm.encodeT(current)
+		case 'V':
+			dm.add("F")
+			current += dm.skipDouble(current, 'V')
+		case 'W':
+			current = dm.encodeW(current)
+		case 'X':
+			// french, such as "breaux"
+			if !(current == dm.last && (dm.stringAt(current-3, 3, "IAU", "EAU") || dm.stringAt(current-2, 2, "AU", "OU"))) {
+				dm.add("KS")
+			}
+			if dm.stringAt(current+1, 1, "C", "X") {
+				current += 2
+			} else {
+				current++
+			}
+		case 'Z':
+			// chinese pinyin, such as "zhao"
+			if dm.at(current+1) == 'H' {
+				dm.add("J")
+				current += 2
+				continue
+			}
+			if dm.stringAt(current+1, 2, "ZO", "ZI", "ZA") || (dm.slavoGermanic && current > 0 && dm.at(current-1) != 'T') {
+				dm.addAlt("S", "TS")
+			} else {
+				dm.add("S")
+			}
+			current += dm.skipDouble(current, 'Z')
+		default:
+			current++
+		}
+	}
+}
+
+// skipDouble returns the number of letters to skip, 2 if the letter is doubled and 1 otherwise.
+func (dm *doubleMetaphone) skipDouble(current int, c byte) int {
+	if dm.at(current+1) == c {
+		return 2
+	}
+	return 1
+}
+
+func (dm *doubleMetaphone) encodeC(current int) int {
+	// various germanic
+	if current > 1 && !dm.vowelAt(current-2) && dm.stringAt(current-1, 3, "ACH") &&
+		dm.at(current+2) != 'I' && (dm.at(current+2) != 'E' || dm.stringAt(current-2, 6, "BACHER", "MACHER")) {
+		dm.add("K")
+		return current + 2
+	}
+	// special case "caesar"
+	if current == 0 && dm.stringAt(current, 6, "CAESAR") {
+		dm.add("S")
+		return current + 2
+	}
+	// italian "chianti"
+	if dm.stringAt(current, 4, "CHIA") {
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CH") {
+		// "michael"
+		if current > 0 && dm.stringAt(current, 4, "CHAE") {
+			dm.addAlt("K", "X")
+			return current + 2
+		}
+		// greek roots, such as "chemistry" and "chorus"
+		if current == 0 && (dm.stringAt(current+1, 5, "HARAC", "HARIS") || dm.stringAt(current+1, 3, "HOR", "HYM", "HIA", "HEM")) &&
+			!dm.stringAt(0, 5, "CHORE") {
+			dm.add("K")
+			return current + 2
+		}
+		// germanic, greek, or otherwise CH for the KH sound
+		if dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") ||
+			dm.stringAt(current-2, 6, "ORCHES", "ARCHIT", "ORCHID") || dm.stringAt(current+2, 1, "T", "S") ||
+			((dm.stringAt(current-1, 1, "A", "O", "U", "E") || current == 0) &&
+				dm.stringAt(current+2, 1, "L", "R", "N", "M", "B", "H", "F", "V", "W", " ")) {
+			dm.add("K")
+		} else if current > 0 {
+			if dm.stringAt(0, 2, "MC") {
+				dm.add("K")
+			} else {
+				dm.addAlt("X", "K")
+			}
+		} else {
+			dm.add("X")
+		}
+		return current + 2
+	}
+	// "czerny"
+	if dm.stringAt(current, 2, "CZ") && !dm.stringAt(current-2, 4, "WICZ") {
+		dm.addAlt("S", "X")
+		return current + 2
+	}
+	// "focaccia"
+	if dm.stringAt(current+1, 3, "CIA") {
+		dm.add("X")
+		return current + 3
+	}
+	// double C, but not "mcclellan"
+	if dm.stringAt(current, 2, "CC") && !(current == 1 && dm.at(0) == 'M') {
+		// "bellocchio", but not "bacchus"
+		if dm.stringAt(current+2, 1, "I", "E", "H") && !dm.stringAt(current+2, 2, "HU") {
+			// "accident", "accede", "succeed"
+			if (current == 1 && dm.at(current-1) == 'A') || dm.stringAt(current-1, 5, "UCCEE", "UCCES") {
+				dm.add("KS")
+			} else {
+				// "bacci", "bertucci" and other italian words
+				dm.add("X")
+			}
+			return current + 3
+		}
+		// Pierce's rule
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CK", "CG", "CQ") {
+		dm.add("K")
+		return current + 2
+	}
+	if dm.stringAt(current, 2, "CI", "CE", "CY") {
+		// italian or english
+		if dm.stringAt(current, 3, "CIO", "CIE", "CIA") {
+			dm.addAlt("S", "X")
+		} else {
+			dm.add("S")
+		}
+		return current + 2
+	}
+	dm.add("K")
+	// "mac caffrey", "mac gregor"
+	if dm.stringAt(current+1, 2, " C", " Q", " G") {
+		return current + 3
+	}
+	if dm.stringAt(current+1, 1, "C", "K", "Q") && !dm.stringAt(current+1, 2, "CE", "CI") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeG(current int) int {
+	if dm.at(current+1) == 'H' {
+		if current > 0 && !dm.vowelAt(current-1) {
+			dm.add("K")
+			return current + 2
+		}
+		// "ghislane", "ghiradelli"
+		if current == 0 {
+			if dm.at(current+2) == 'I' {
+				dm.add("J")
+			} else {
+				dm.add("K")
+			}
+			return current + 2
+		}
+		// Parker's rule, such as "hugh"
+		if (current > 1 && dm.stringAt(current-2, 1, "B", "H", "D")) ||
+			(current > 2 && dm.stringAt(current-3, 1, "B", "H", "D")) ||
+			(current > 3 && dm.stringAt(current-4, 1, "B", "H")) {
+			return current + 2
+		}
+		// "laugh", "mclaughlin", "cough", "gough", "rough", "tough"
+		if current > 2 && dm.at(current-1) == 'U' && dm.stringAt(current-3, 1, "C", "G", "L", "R", "T") {
+			dm.add("F")
+		} else if current > 0 && dm.at(current-1) != 'I' {
+			dm.add("K")
+		}
+		return current + 2
+	}
+	if dm.at(current+1) == 'N' {
+		if current == 1 && dm.vowelAt(0) && !dm.slavoGermanic {
+			dm.addAlt("KN", "N")
+		} else if !dm.stringAt(current+2, 2, "EY") && dm.at(current+1) != 'Y' && !dm.slavoGermanic {
+			// not "cagney"
+			dm.addAlt("N", "KN")
+		} else {
+			dm.add("KN")
+		}
+		return current + 2
+	}
+	// "tagliaro"
+	if dm.stringAt(current+1, 2, "LI") && !dm.slavoGermanic {
+		dm.addAlt("KL", "L")
+		return current + 2
+	}
+	// -ges-, -gep-, -gel-, -gie- at the beginning
+	if current == 0 && (dm.at(current+1) == 'Y' ||
+		dm.stringAt(current+1, 2, "ES", "EP", "EB", "EL", "EY", "IB", "IL", "IN", "IE", "EI", "ER")) {
+		dm.addAlt("K", "J")
+		return current + 2
+	}
+	// -ger-, -gy-
+	if (dm.stringAt(current+1, 2, "ER") || dm.at(current+1) == 'Y') && !dm.stringAt(0, 6, "DANGER", "RANGER", "MANGER") &&
+		!dm.stringAt(current-1, 1, "E", "I") && !dm.stringAt(current-1, 3, "RGY", "OGY") {
+		dm.addAlt("K", "J")
+		return current + 2
+	}
+	// italian, such as "biaggi"
+	if dm.stringAt(current+1, 1, "E", "I", "Y") || dm.stringAt(current-1, 4, "AGGI", "OGGI") {
+		if dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") || dm.stringAt(current+1, 2, "ET") {
+			// obviously germanic
+			dm.add("K")
+		} else if dm.stringAt(current+1, 4, "IER ") {
+			// always soft with a french ending
+			dm.add("J")
+		} else {
+			dm.addAlt("J", "K")
+		}
+		return current + 2
+	}
+	dm.add("K")
+	return current + dm.skipDouble(current, 'G')
+}
+
+func (dm *doubleMetaphone) encodeJ(current int) int {
+	// obviously spanish, "jose", "san jacinto"
+	if dm.stringAt(current, 4, "JOSE") || dm.stringAt(0, 4, "SAN ") {
+		if (current == 0 && dm.at(current+4) == ' ') || dm.stringAt(0, 4, "SAN ") {
+			dm.add("H")
+		} else {
+			dm.addAlt("J", "H")
+		}
+		return current + 1
+	}
+	if current == 0 && !dm.stringAt(current, 4, "JOSE") {
+		// "yankelovich", "jankelowicz"
+		dm.addAlt("J", "A")
+	} else if dm.vowelAt(current-1) && !dm.slavoGermanic && (dm.at(current+1) == 'A' || dm.at(current+1) == 'O') {
+		// spanish pronunciation of "bajador"
+		dm.addAlt("J", "H")
+	} else if current == dm.last {
+		dm.addAlt("J", "")
+	} else if !dm.stringAt(current+1, 1, "L", "T", "K", "S", "N", "M", "B", "Z") && !dm.stringAt(current-1, 1, "S", "K", "L") {
+		dm.add("J")
+	}
+	return current + dm.skipDouble(current, 'J')
+}
+
+func (dm *doubleMetaphone) encodeS(current int) int {
+	// "island", "isle", "carlisle", "carlysle"
+	if dm.stringAt(current-1, 3, "ISL", "YSL") {
+		return current + 1
+	}
+	// "sugar-"
+	if current == 0 && dm.stringAt(current, 5, "SUGAR") {
+		dm.addAlt("X", "S")
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SH") {
+		if dm.stringAt(current+1, 4, "HEIM", "HOEK", "HOLM", "HOLZ") {
+			// germanic
+			dm.add("S")
+		} else {
+			dm.add("X")
+		}
+		return current + 2
+	}
+	// italian and armenian
+	if dm.stringAt(current, 3, "SIO", "SIA") || dm.stringAt(current, 4, "SIAN") {
+		if !dm.slavoGermanic {
+			dm.addAlt("S", "X")
+		} else {
+			dm.add("S")
+		}
+		return current + 3
+	}
+	// german and anglicisations, "smith" matching "schmidt" and "snider" matching "schneider";
+	// also -sz- in slavic languages, although it is pronounced S in hungarian
+	if (current == 0 && dm.stringAt(current+1, 1, "M", "N", "L", "W")) || dm.stringAt(current+1, 1, "Z") {
+		dm.addAlt("S", "X")
+		if dm.stringAt(current+1, 1, "Z") {
+			return current + 2
+		}
+		return current + 1
+	}
+	if dm.stringAt(current, 2, "SC") {
+		// Schlesinger's rule
+		if dm.at(current+2) == 'H' {
+			// dutch origin, such as "school" and "schooner"
+			if dm.stringAt(current+3, 2, "OO", "ER", "EN", "UY", "ED", "EM") {
+				// "schermerhorn", "schenker"
+				if dm.stringAt(current+3, 2, "ER", "EN") {
+					dm.addAlt("X", "SK")
+				} else {
+					dm.add("SK")
+				}
+				return current + 3
+			}
+			if current == 0 && !dm.vowelAt(3) && dm.at(3) != 'W' {
+				dm.addAlt("X", "S")
+			} else {
+				dm.add("X")
+			}
+			return current + 3
+		}
+		if dm.stringAt(current+2, 1, "I", "E", "Y") {
+			dm.add("S")
+			return current + 3
+		}
+		dm.add("SK")
+		return current + 3
+	}
+	// french, such as "resnais" and "artois"
+	if current == dm.last && dm.stringAt(current-2, 2, "AI", "OI") {
+		dm.addAlt("", "S")
+	} else {
+		dm.add("S")
+	}
+	if dm.stringAt(current+1, 1, "S", "Z") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeT(current int) int {
+	if dm.stringAt(current, 4, "TION") {
+		dm.add("X")
+		return current + 3
+	}
+	if dm.stringAt(current, 3, "TIA", "TCH") {
+		dm.add("X")
+		return current + 3
+	}
+	if dm.stringAt(current, 2, "TH") || dm.stringAt(current, 3, "TTH") {
+		// "thomas", "thames", or germanic
+		if dm.stringAt(current+2, 2, "OM", "AM") || dm.stringAt(0, 4, "VAN ", "VON ") || dm.stringAt(0, 3, "SCH") {
+			dm.add("T")
+		} else {
+			dm.addAlt("0", "T")
+		}
+		return current + 2
+	}
+	dm.add("T")
+	if dm.stringAt(current+1, 1, "T", "D") {
+		return current + 2
+	}
+	return current + 1
+}
+
+func (dm *doubleMetaphone) encodeW(current int) int {
+	// can also be in the middle of the word
+	if dm.stringAt(current, 2, "WR") {
+		dm.add("R")
+		return current + 2
+	}
+	if current == 0 && (dm.vowelAt(current+1) || dm.stringAt(current, 2, "WH")) {
+		if dm.vowelAt(current + 1) {
+			// "wasserman" matching "vasserman"
+			dm.addAlt("A", "F")
+		} else {
+			// "uomo" matching "womo"
+			dm.add("A")
+		}
+	}
+	// "arnow" matching "arnoff"
+	if (current == dm.last && dm.vowelAt(current-1)) || dm.stringAt(current-1, 5, "EWSKI", "EWSKY", "OWSKI", "OWSKY") ||
+		dm.stringAt(0, 3, "SCH") {
+		dm.addAlt("", "F")
+		return current + 1
+	}
+	// polish, such as "filipowicz"
+	if dm.stringAt(current, 4, "WICZ", "WITZ") {
+		dm.addAlt("TS", "FX")
+		return current + 4
+	}
+	return current + 1
+}
@@ -0,0 +1,30 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestDoubleMetaphone(t *testing.T) {
+	type testCase struct {
+		st        string
+		primary   string
+		secondary string
+	}
+	cases := []testCase{
+		{st: "Smith", primary: "SM0", secondary: "XMT"},
+		{st: "Schmidt", primary: "XMT", secondary: "SMT"},
+		{st: "Jose", primary: "HS", secondary: "HS"},
+		{st: "Xavier", primary: "SF", secondary: "SFR"},
+		{st: "Michael", primary: "MKL", secondary: "MXL"},
+		{st: "Arnow", primary: "ARN", secondary: "ARNF"},
+		{st: "Caesar", primary: "SSR", secondary: "SSR"},
+		{st: "laugh", primary: "LF", secondary: "LF"},
+		{st: "edge", primary: "AJ", secondary: "AJ"},
+		{st: "", primary: "", secondary: ""}}
+	for _, c := range cases {
+		primary, secondary := DoubleMetaphone{}.Codes(c.st)
+		if primary != c.primary || secondary != c.secondary {
+			t.Errorf("Failed: expected %v and %v, recieved %v and %v", c.primary, c.secondary, primary, secondary)
+		}
+	}
+}
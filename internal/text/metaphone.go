@@ -0,0 +1,153 @@
+package text
+
+/*
+Implementation of the original Metaphone algorithm by Lawrence Philips: https://en.wikipedia.org/wiki/Metaphone
+The rules follow the widely used Apache Commons Codec implementation.
+*/
+
+// Metaphone is the Metaphone encoder.
+type Metaphone struct {
+	// MaxLength is the maximum length of the codes. The zero value means 4.
+	MaxLength int
+}
+
+// Encode returns the Metaphone code of the word.
+func (e Metaphone) Encode(s string) string {
+	maxLength := e.MaxLength
+	if maxLength <= 0 {
+		maxLength = 4
+	}
+	w := upperLetters(s)
+	if len(w) <= 1 {
+		return string(w)
+	}
+	// initial exceptions
+	switch {
+	case (w[0] == 'K' || w[0] == 'G' || w[0] == 'P') && w[1] == 'N', w[0] == 'A' && w[1] == 'E', w[0] == 'W' && w[1] == 'R':
+		w = w[1:]
+	case w[0] == 'W' && w[1] == 'H':
+		w = w[1:]
+		w[0] = 'W'
+	case w[0] == 'X':
+		w[0] = 'S'
+	}
+	at := func(i int) byte {
+		if i < 0 || i >= len(w) {
+			return 0
+		}
+		return w[i]
+	}
+	region := func(i int, sub string) bool {
+		return i >= 0 && i+len(sub) <= len(w) && string(w[i:i+len(sub)]) == sub
+	}
+	frontVowel := func(c byte) bool { return c == 'E' || c == 'I' || c == 'Y' }
+	last := len(w) - 1
+	code := make([]byte, 0, maxLength+1)
+	for n := 0; n < len(w) && len(code) < maxLength; n++ {
+		c := w[n]
+		if c != 'C' && at(n-1) == c {
+			continue
+		}
+		switch c {
+		case 'A', 'E', 'I', 'O', 'U':
+			if n == 0 {
+				code = append(code, c)
+			}
+		case 'B':
+			if !(at(n-1) == 'M' && n == last) {
+				code = append(code, 'B')
+			}
+		case 'C':
+			switch {
+			case at(n-1) == 'S' && frontVowel(at(n+1)):
+				// silent in SCI, SCE and SCY
+			case region(n, "CIA"):
+				code = append(code, 'X')
+			case frontVowel(at(n + 1)):
+				code = append(code, 'S')
+			case at(n-1) == 'S' && at(n+1) == 'H':
+				code = append(code, 'K')
+			case at(n+1) == 'H':
+				if n == 0 && len(w) >= 3 && isVowel(at(2)) {
+					code = append(code, 'K')
+				} else {
+					code = append(code, 'X')
+				}
+			default:
+				code = append(code, 'K')
+			}
+		case 'D':
+			if at(n+1) == 'G' && frontVowel(at(n+2)) {
+				code = append(code, 'J')
+				n += 2
+			} else {
+				code = append(code, 'T')
+			}
+		case 'G':
+			switch {
+			case at(n+1) == 'H' && (n+1 == last || !isVowel(at(n+2))):
+				// silent in GH, at the end or before a consonant
+			case n > 0 && (region(n, "GN") || region(n, "GNED")):
+				// silent in GN and GNED
+			case frontVowel(at(n+1)) && at(n-1) != 'G':
+				code = append(code, 'J')
+			default:
+				code = append(code, 'K')
+			}
+		case 'H':
+			if n != last && !(n > 0 && isVarson(at(n-1))) && isVowel(at(n+1)) {
+				code = append(code, 'H')
+			}
+		case 'F', 'J', 'L', 'M', 'N', 'R':
+			code = append(code, c)
+		case 'K':
+			if at(n-1) != 'C' {
+				code = append(code, 'K')
+			}
+		case 'P':
+			if at(n+1) == 'H' {
+				code = append(code, 'F')
+			} else {
+				code = append(code, 'P')
+			}
+		case 'Q':
+			code = append(code, 'K')
+		case 'S':
+			if region(n, "SH") || region(n, "SIO") || region(n, "SIA") {
+				code = append(code, 'X')
+			} else {
+				code = append(code, 'S')
+			}
+		case 'T':
+			switch {
+			case region(n, "TIA") || region(n, "TIO"):
+				code = append(code, 'X')
+			case region(n, "TCH"):
+				// silent in TCH
+			case at(n+1) == 'H':
+				code = append(code, '0')
+			default:
+				code = append(code, 'T')
+			}
+		case 'V':
+			code = append(code, 'F')
+		case 'W', 'Y':
+			if isVowel(at(n + 1)) {
+				code = append(code, c)
+			}
+		case 'X':
+			code = append(code, 'K', 'S')
+		case 'Z':
+			code = append(code, 'S')
+		}
+	}
+	if len(code) > maxLength {
+		code = code[:maxLength]
+	}
+	return string(code)
+}
+
+// isVarson checks if a letter makes a following H silent.
+func isVarson(c byte) bool {
+	return c == 'C' || c == 'S' || c == 'P' || c == 'T' || c == 'G'
+}
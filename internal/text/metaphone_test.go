@@ -0,0 +1,31 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestMetaphone(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected string
+	}
+	cases := []testCase{
+		{st: "knight", expected: "NT"},
+		{st: "wright", expected: "RT"},
+		{st: "Xavier", expected: "SFR"},
+		{st: "school", expected: "SKL"},
+		{st: "character", expected: "KRKT"},
+		{st: "Thumb", expected: "0M"},
+		{st: "happy", expected: "HP"},
+		{st: "a", expected: "A"},
+		{st: "", expected: ""}}
+	for _, c := range cases {
+		out := Metaphone{}.Encode(c.st)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+		}
+	}
+	if out := (Metaphone{MaxLength: 8}).Encode("testing"); out != "TSTNK" {
+		t.Errorf("Failed: expected %v, recieved %v", "TSTNK", out)
+	}
+}
@@ -0,0 +1,93 @@
+package text
+
+/*
+Implementation of the New York State Identification and Intelligence System (NYSIIS) algorithm:
+https://en.wikipedia.org/wiki/New_York_State_Identification_and_Intelligence_System
+*/
+
+import (
+	"bytes"
+)
+
+// NYSIIS is the NYSIIS encoder.
+type NYSIIS struct {
+	// MaxLength is the maximum length of the codes. The zero value means 6, as in the original algorithm.
+	MaxLength int
+}
+
+// nysiisPrefixes are the translations of the first letters of a word.
+var nysiisPrefixes = [][2]string{{"MAC", "MCC"}, {"KN", "NN"}, {"K", "C"}, {"PH", "FF"}, {"PF", "FF"}, {"SCH", "SSS"}}
+
+// nysiisSuffixes are the translations of the last letters of a word.
+var nysiisSuffixes = [][2]string{{"EE", "Y"}, {"IE", "Y"}, {"DT", "D"}, {"RT", "D"}, {"RD", "D"}, {"NT", "D"}, {"ND", "D"}}
+
+// Encode returns the NYSIIS code of the word.
+func (e NYSIIS) Encode(s string) string {
+	maxLength := e.MaxLength
+	if maxLength <= 0 {
+		maxLength = 6
+	}
+	w := upperLetters(s)
+	if len(w) == 0 {
+		return ""
+	}
+	for _, p := range nysiisPrefixes {
+		if bytes.HasPrefix(w, []byte(p[0])) {
+			w = append([]byte(p[1]), w[len(p[0]):]...)
+			break
+		}
+	}
+	for _, p := range nysiisSuffixes {
+		if bytes.HasSuffix(w, []byte(p[0])) {
+			w = append(w[:len(w)-len(p[0])], p[1]...)
+			break
+		}
+	}
+	key := []byte{w[0]}
+	for i := 1; i < len(w); i++ {
+		next := byte(0)
+		if i+1 < len(w) {
+			next = w[i+1]
+		}
+		switch c := w[i]; {
+		case c == 'E' && next == 'V':
+			w[i], w[i+1] = 'A', 'F'
+		case isVowel(c):
+			w[i] = 'A'
+		case c == 'Q':
+			w[i] = 'G'
+		case c == 'Z':
+			w[i] = 'S'
+		case c == 'M':
+			w[i] = 'N'
+		case c == 'K' && next == 'N':
+			w[i] = 'N'
+		case c == 'K':
+			w[i] = 'C'
+		case c == 'S' && next == 'C' && i+2 < len(w) && w[i+2] == 'H':
+			w[i+1], w[i+2] = 'S', 'S'
+		case c == 'P' && next == 'H':
+			w[i], w[i+1] = 'F', 'F'
+		case c == 'H' && (!isVowel(w[i-1]) || !isVowel(next)):
+			w[i] = w[i-1]
+		case c == 'W' && isVowel(w[i-1]):
+			w[i] = w[i-1]
+		}
+		if w[i] != key[len(key)-1] {
+			key = append(key, w[i])
+		}
+	}
+	if len(key) > 1 && key[len(key)-1] == 'S' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > 1 && bytes.HasSuffix(key, []byte("AY")) {
+		key = append(key[:len(key)-2], 'Y')
+	}
+	if len(key) > 1 && key[len(key)-1] == 'A' {
+		key = key[:len(key)-1]
+	}
+	if len(key) > maxLength {
+		key = key[:maxLength]
+	}
+	return string(key)
+}
@@ -0,0 +1,25 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestNYSIIS(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected string
+	}
+	cases := []testCase{
+		{st: "Brown", expected: "BRAN"},
+		{st: "Brian", expected: "BRAN"},
+		{st: "Knight", expected: "NAGT"},
+		{st: "Mitchell", expected: "MATCAL"},
+		{st: "Bishop", expected: "BASAP"},
+		{st: "", expected: ""}}
+	for _, c := range cases {
+		out := NYSIIS{}.Encode(c.st)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+		}
+	}
+}
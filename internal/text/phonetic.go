@@ -0,0 +1,74 @@
+package text
+
+import (
+	"strings"
+)
+
+/*
+Phonetic encoders. Every encoder maps a word to a code, so that words that sound alike share a code.
+The encoders ignore the characters that are not letters, and return an empty code for a word with no letters.
+*/
+
+// PhoneticEncoder encodes a word into its phonetic code.
+type PhoneticEncoder interface {
+	Encode(s string) string
+}
+
+// MultiEncoder is a PhoneticEncoder that can encode a word into several alternative codes,
+// such as the primary and secondary codes of Double Metaphone.
+type MultiEncoder interface {
+	PhoneticEncoder
+	// EncodeAll returns the distinct codes of the word, starting with the one returned by Encode.
+	EncodeAll(s string) []string
+}
+
+// Codes returns all the codes of a word: the alternative codes of a MultiEncoder, or the single code
+// of any other encoder. Empty codes are omitted.
+func Codes(e PhoneticEncoder, s string) []string {
+	if me, ok := e.(MultiEncoder); ok {
+		res := []string{}
+		for _, c := range me.EncodeAll(s) {
+			if c != "" {
+				res = append(res, c)
+			}
+		}
+		return res
+	}
+	if c := e.Encode(s); c != "" {
+		return []string{c}
+	}
+	return []string{}
+}
+
+// latinFolds maps the accented Latin letters to their unaccented upper-case form.
+var latinFolds = map[rune]string{
+	'À': "A", 'Á': "A", 'Â': "A", 'Ã': "A", 'Ä': "A", 'Å': "A", 'Ą': "A",
+	'Ç': "C", 'Ć': "C", 'Č': "C",
+	'È': "E", 'É': "E", 'Ê': "E", 'Ë': "E", 'Ę': "E",
+	'Ì': "I", 'Í': "I", 'Î': "I", 'Ï': "I",
+	'Ł': "L", 'Ñ': "N", 'Ń': "N",
+	'Ò': "O", 'Ó': "O", 'Ô': "O", 'Õ': "O", 'Ö': "O", 'Ø': "O",
+	'Ś': "S", 'Š': "S", 'ß': "S",
+	'Ù': "U", 'Ú': "U", 'Û': "U", 'Ü': "U",
+	'Ý': "Y", 'Ÿ': "Y", 'Ź': "Z", 'Ż': "Z", 'Ž': "Z",
+}
+
+// upperLetters returns the letters of a word in upper-case ASCII, folding the accented Latin letters
+// and dropping every other character.
+func upperLetters(s string) []byte {
+	res := make([]byte, 0, len(s))
+	for _, r := range strings.ToUpper(s) {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			res = append(res, byte(r))
+		case latinFolds[r] != "":
+			res = append(res, latinFolds[r]...)
+		}
+	}
+	return res
+}
+
+// isVowel checks if an upper-case ASCII letter is a vowel, not counting Y.
+func isVowel(c byte) bool {
+	return c == 'A' || c == 'E' || c == 'I' || c == 'O' || c == 'U'
+}
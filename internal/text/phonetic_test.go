@@ -0,0 +1,50 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestCodes(t *testing.T) {
+	type testCase struct {
+		encoder  PhoneticEncoder
+		st       string
+		expected []string
+	}
+	cases := []testCase{
+		{encoder: AmericanSoundex{}, st: "happy", expected: []string{"H100"}},
+		{encoder: AmericanSoundex{}, st: "", expected: []string{}},
+		{encoder: DoubleMetaphone{}, st: "Schmidt", expected: []string{"XMT", "SMT"}},
+		{encoder: DoubleMetaphone{}, st: "happy", expected: []string{"HP"}},
+		{encoder: DaitchMokotoff{}, st: "Peters", expected: []string{"739400", "734000"}}}
+	for _, c := range cases {
+		out := Codes(c.encoder, c.st)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+}
+
+func FuzzEncoders(f *testing.F) {
+	encoders := []PhoneticEncoder{
+		LegacySoundex{}, AmericanSoundex{}, RefinedSoundex{}, Metaphone{}, DoubleMetaphone{},
+		NYSIIS{}, Cologne{}, DaitchMokotoff{},
+	}
+	for _, s := range []string{"", "happy", "Schwarzenegger", "Müller-Lüdenscheidt", "\xff\xfe", "SCHTSCH", "X", "wh"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		for _, e := range encoders {
+			code := e.Encode(s)
+			codes := Codes(e, s)
+			if code != "" && (len(codes) == 0 || codes[0] != code) {
+				t.Errorf("Failed: expected %q to lead %v", code, codes)
+			}
+		}
+	})
+}
@@ -2,25 +2,118 @@
 package text
 
 import (
-	"regexp"
-	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 /*
 Create text vecs, and check if it is a valid text based on subject, topic, and sentiment text
 */
 
+// Token is a single space-separated token of a text.
+type Token struct {
+	// Raw is the token as it appears in the text.
+	Raw string
+	// Word is the processed token, as returned by `GenerateValidWords`.
+	Word string
+	// Start is the byte offset of the token in the text.
+	Start int
+}
+
+// Tokenizer splits texts into tokens, reusing its buffers between calls.
+// The zero value is ready to use. A Tokenizer is not safe for concurrent use.
+type Tokenizer struct {
+	tokens []Token
+	buf    []byte
+	// spans are the offsets of the processed words in buf, or -1 when the word is its raw token.
+	spans [][2]int
+}
+
+// Tokenize splits the text on spaces, and returns the raw and processed form of every token.
+// The tokens are aligned with the output of `GenerateValidWords`. They are only valid until the
+// next call: the processed words that differ from their raw token share a single allocation.
+func (tz *Tokenizer) Tokenize(text string) []Token {
+	tz.tokens, tz.buf, tz.spans = tz.tokens[:0], tz.buf[:0], tz.spans[:0]
+	start := 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] != ' ' {
+			continue
+		}
+		raw := text[start:i]
+		tk, span := Token{Raw: raw, Word: raw, Start: start}, [2]int{-1, -1}
+		if !isProcessed(raw) {
+			tk.Word = ""
+			span[0] = len(tz.buf)
+			tz.buf = AppendWord(tz.buf, raw)
+			span[1] = len(tz.buf)
+		}
+		tz.tokens = append(tz.tokens, tk)
+		tz.spans = append(tz.spans, span)
+		start = i + 1
+	}
+	if len(tz.buf) > 0 {
+		words := string(tz.buf)
+		for i, span := range tz.spans {
+			if span[0] >= 0 {
+				tz.tokens[i].Word = words[span[0]:span[1]]
+			}
+		}
+	}
+	return tz.tokens
+}
+
+// Tokenize splits the text on spaces, and returns the raw and processed form of every token.
+// The tokens are aligned with the output of `GenerateValidWords`.
+func Tokenize(text string) []Token {
+	var tz Tokenizer
+	return tz.Tokenize(text)
+}
+
 // GenerateValidWords returns a list of words processed from the input text.
 func GenerateValidWords(text string) []string {
-	wordsColl := strings.Split(text, " ")
-	validWords := []string{}
-	for _, w := range wordsColl {
-		lowerCased := strings.ToLower(w)
-		newLineRemoved := strings.ReplaceAll(lowerCased, "\n", "")
-		spacesTrimmed := strings.TrimSpace(newLineRemoved)
-		reg := regexp.MustCompile("[^A-Za-z0-9]+")
-		processedWord := reg.ReplaceAllString(spacesTrimmed, "")
-		validWords = append(validWords, processedWord)
+	var tz Tokenizer
+	tokens := tz.Tokenize(text)
+	validWords := make([]string, len(tokens))
+	for i, tk := range tokens {
+		validWords[i] = tk.Word
 	}
 	return validWords
 }
+
+// AppendWord appends the processed form of a raw token to dst: its letters and digits, lower-cased,
+// without any other character. Only the characters that lower-case to ASCII letters are kept.
+func AppendWord(dst []byte, raw string) []byte {
+	for i := 0; i < len(raw); {
+		c := raw[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c >= 'a' && c <= 'z', c >= '0' && c <= '9':
+				dst = append(dst, c)
+			case c >= 'A' && c <= 'Z':
+				dst = append(dst, c+'a'-'A')
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(raw[i:])
+		if l := unicode.ToLower(r); l < utf8.RuneSelf && isLowerAlnum(byte(l)) {
+			dst = append(dst, byte(l))
+		}
+		i += size
+	}
+	return dst
+}
+
+// isProcessed checks if a raw token is already in its processed form.
+func isProcessed(raw string) bool {
+	for i := 0; i < len(raw); i++ {
+		if !isLowerAlnum(raw[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func isLowerAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9')
+}
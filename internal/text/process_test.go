@@ -12,3 +12,60 @@ func TestGenerateValidWords(t *testing.T) {
 		t.Errorf("Failed: expected %v, recieved %v", expected, out)
 	}
 }
+
+func TestTokenize(t *testing.T) {
+	testCase := "I'm  happy\n now! "
+	expected := []Token{
+		{Raw: "I'm", Word: "im", Start: 0},
+		{Raw: "", Word: "", Start: 4},
+		{Raw: "happy\n", Word: "happy", Start: 5},
+		{Raw: "now!", Word: "now", Start: 12},
+		{Raw: "", Word: "", Start: 17}}
+	out := Tokenize(testCase)
+	if len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v", expected, out)
+	}
+	words := GenerateValidWords(testCase)
+	for i := range out {
+		if out[i] != expected[i] || out[i].Word != words[i] {
+			t.Errorf("Failed: expected %v, recieved %v", expected[i], out[i])
+		}
+	}
+}
+
+func FuzzTokenize(f *testing.F) {
+	for _, s := range []string{"", " ", "I'm happy\n now! ", "\xff - \xc3", "a  b"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		tokens := Tokenize(s)
+		words := GenerateValidWords(s)
+		if len(tokens) != len(words) {
+			t.Fatalf("Failed: expected %v tokens, recieved %v", len(words), len(tokens))
+		}
+		for i, tk := range tokens {
+			if tk.Word != words[i] || s[tk.Start:tk.Start+len(tk.Raw)] != tk.Raw {
+				t.Errorf("Failed: token %v is not aligned with %q", tk, words[i])
+			}
+		}
+	})
+}
+
+func BenchmarkTokenizer(b *testing.B) {
+	text := "I'm so happy that Covid is getting under control! #stayhome"
+	var tz Tokenizer
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		tz.Tokenize(text)
+	}
+}
+
+func BenchmarkGenerateValidWords(b *testing.B) {
+	text := "I'm so happy that Covid is getting under control! #stayhome"
+	b.SetBytes(int64(len(text)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GenerateValidWords(text)
+	}
+}
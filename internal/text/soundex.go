@@ -6,36 +6,144 @@ This implementation code is copied directly from https://github.com/xrash/smetri
 */
 
 import (
-	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
+// soundexCodes are the Soundex digits of the letters, from A to Z; the letters that are not coded have a 0.
+var soundexCodes = [26]byte{
+	'A' - 'A': 0, 'B' - 'A': '1', 'C' - 'A': '2', 'D' - 'A': '3', 'E' - 'A': 0, 'F' - 'A': '1', 'G' - 'A': '2',
+	'H' - 'A': 0, 'I' - 'A': 0, 'J' - 'A': '2', 'K' - 'A': '2', 'L' - 'A': '4', 'M' - 'A': '5', 'N' - 'A': '5',
+	'O' - 'A': 0, 'P' - 'A': '1', 'Q' - 'A': '2', 'R' - 'A': '6', 'S' - 'A': '2', 'T' - 'A': '3', 'U' - 'A': 0,
+	'V' - 'A': '1', 'W' - 'A': 0, 'X' - 'A': '2', 'Y' - 'A': 0, 'Z' - 'A': '2',
+}
+
 // Soundex encoding is a phonetic algorithm that considers how the words sound in english.
 // Soundex maps a name to a 4-byte string consisting of the first letter of the original string and three numbers.
 // Strings that sound similar should map to the same thing.
+//
+// This variant skips repeated letters rather than repeated codes, and does not apply the H/W rule;
+// it is kept as is, since the built-in indexes are keyed by its codes. See `AmericanSoundex` for the standard variant.
+// The code starts with the first ASCII letter or digit of the string, and is empty if there is none.
 func Soundex(s string) string {
-	m := map[byte]string{
-		'B': "1", 'P': "1", 'F': "1", 'V': "1",
-		'C': "2", 'S': "2", 'K': "2", 'G': "2", 'J': "2", 'Q': "2", 'X': "2", 'Z': "2",
-		'D': "3", 'T': "3",
-		'L': "4",
-		'M': "5", 'N': "5",
-		'R': "6",
-	}
-	s = strings.ToUpper(s)
-	r := string(s[0])
-	p := s[0]
-	for i := 1; i < len(s) && len(r) < 4; i++ {
-		c := s[i]
-		if (c < 'A' || c > 'Z') || (c == p) {
+	var buf [4]byte
+	return string(AppendSoundex(buf[:0], s))
+}
+
+// AppendSoundex appends the `Soundex` code of s to dst, without allocating when dst has room for 4 bytes.
+func AppendSoundex(dst []byte, s string) []byte {
+	start := len(dst)
+	var p byte
+	for i := 0; i < len(s) && len(dst)-start < 4; {
+		c, size := upperASCII(s, i)
+		i += size
+		if len(dst) == start {
+			// the first letter or digit starts the code
+			if (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') {
+				dst = append(dst, c)
+				p = c
+			}
+			continue
+		}
+		if c < 'A' || c > 'Z' || c == p {
 			continue
 		}
 		p = c
-		if n, ok := m[c]; ok {
-			r += n
+		if n := soundexCodes[c-'A']; n != 0 {
+			dst = append(dst, n)
+		}
+	}
+	if len(dst) == start {
+		return dst
+	}
+	for len(dst)-start < 4 {
+		dst = append(dst, '0')
+	}
+	return dst
+}
+
+// upperASCII returns the upper-case form of the character at position i of s, if it is an ASCII character
+// (or a character that upper-cases to one), along with the size of the character in bytes.
+// Any other character is returned as a 0.
+func upperASCII(s string, i int) (byte, int) {
+	c := s[i]
+	if c < utf8.RuneSelf {
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		return c, 1
+	}
+	r, size := utf8.DecodeRuneInString(s[i:])
+	if u := unicode.ToUpper(r); u < utf8.RuneSelf {
+		return byte(u), size
+	}
+	return 0, size
+}
+
+// LegacySoundex is the PhoneticEncoder of `Soundex`, used to build the built-in indexes.
+type LegacySoundex struct{}
+
+// Encode returns the `Soundex` code of the word.
+func (LegacySoundex) Encode(s string) string {
+	return Soundex(s)
+}
+
+// americanSoundexCodes are the American Soundex digits of the letters, from A to Z.
+// Vowels are coded 0, and H and W are coded with a space, since they do not separate equal codes.
+const americanSoundexCodes = "0123012 02245501262301 202"
+
+// AmericanSoundex is the standard American Soundex encoder, as used by the US census:
+// letters with the same code separated by H or W are coded once, while a vowel in between makes them coded twice.
+type AmericanSoundex struct{}
+
+// Encode returns the 4-byte American Soundex code of the word.
+func (AmericanSoundex) Encode(s string) string {
+	letters := upperLetters(s)
+	if len(letters) == 0 {
+		return ""
+	}
+	res := []byte{letters[0]}
+	last := americanSoundexCodes[letters[0]-'A']
+	for _, c := range letters[1:] {
+		code := americanSoundexCodes[c-'A']
+		if code == ' ' {
+			continue
 		}
+		if code != '0' && code != last {
+			res = append(res, code)
+			if len(res) == 4 {
+				break
+			}
+		}
+		last = code
+	}
+	for len(res) < 4 {
+		res = append(res, '0')
+	}
+	return string(res)
+}
+
+// refinedSoundexCodes are the Refined Soundex digits of the letters, from A to Z.
+const refinedSoundexCodes = "01360240043788015936020505"
+
+// RefinedSoundex is the Refined Soundex encoder, which splits the letters in more groups than Soundex
+// and does not truncate the code.
+type RefinedSoundex struct{}
+
+// Encode returns the Refined Soundex code of the word.
+func (RefinedSoundex) Encode(s string) string {
+	letters := upperLetters(s)
+	if len(letters) == 0 {
+		return ""
 	}
-	for i := len(r); i < 4; i++ {
-		r += "0"
+	res := []byte{letters[0]}
+	var last byte
+	for _, c := range letters {
+		code := refinedSoundexCodes[c-'A']
+		if code != last {
+			res = append(res, code)
+		}
+		last = code
 	}
-	return r
+	return string(res)
 }
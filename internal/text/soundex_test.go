@@ -12,7 +12,11 @@ func TestSoundex(t *testing.T) {
 	cases := []testCase{
 		{st: "I'm", expected: "I500"},
 		{st: "I am", expected: "I500"},
-		{st: "am", expected: "A500"}}
+		{st: "am", expected: "A500"},
+		{st: "", expected: ""},
+		{st: "-", expected: ""},
+		{st: "'happy", expected: "H100"},
+		{st: "\xc3\xa9t\xe9", expected: "T000"}}
 	for _, c := range cases {
 		out := Soundex(c.st)
 		if out != c.expected {
@@ -20,3 +24,69 @@ func TestSoundex(t *testing.T) {
 		}
 	}
 }
+
+func TestAmericanSoundex(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected string
+	}
+	cases := []testCase{
+		{st: "Robert", expected: "R163"},
+		{st: "Rupert", expected: "R163"},
+		{st: "Ashcraft", expected: "A261"},
+		{st: "Tymczak", expected: "T522"},
+		{st: "Pfister", expected: "P236"},
+		{st: "Honeyman", expected: "H555"},
+		{st: "I'm", expected: "I500"},
+		{st: "", expected: ""},
+		{st: "!?", expected: ""}}
+	for _, c := range cases {
+		out := AmericanSoundex{}.Encode(c.st)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+		}
+	}
+}
+
+func TestRefinedSoundex(t *testing.T) {
+	type testCase struct {
+		st       string
+		expected string
+	}
+	cases := []testCase{
+		{st: "testing", expected: "T6036084"},
+		{st: "Robert", expected: "R901096"},
+		{st: "Rupert", expected: "R901096"},
+		{st: "", expected: ""}}
+	for _, c := range cases {
+		out := RefinedSoundex{}.Encode(c.st)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+		}
+	}
+}
+
+func FuzzSoundex(f *testing.F) {
+	for _, s := range []string{"", "I'm", "happy", "-", "\xc3", "éclair", "2day"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		out := Soundex(s)
+		if out != "" && len(out) != 4 {
+			t.Errorf("Failed: expected a 4-byte code, recieved %q", out)
+		}
+		for i := 0; i < len(out); i++ {
+			if out[i] >= 0x80 {
+				t.Errorf("Failed: expected an ASCII code, recieved %q", out)
+			}
+		}
+	})
+}
+
+func BenchmarkAppendSoundex(b *testing.B) {
+	var buf [4]byte
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		AppendSoundex(buf[:0], "enthusiastic")
+	}
+}
@@ -0,0 +1,56 @@
+package text
+
+/*
+Light suffix stripping, to recognize the inflections of a word ("surprise", "surprised", "surprises").
+It is not a full stemmer: it only removes the most common English inflectional suffixes.
+*/
+
+// stemSuffixes are the suffixes removed by `Stem`, the longest first.
+var stemSuffixes = []string{"ness", "ing", "est", "ied", "ies", "ed", "es", "er", "ly", "s"}
+
+// Stem returns the stem of a processed (lowercase) word, removing its inflectional suffix and final "e".
+// A suffix is only removed if at least three letters remain, two for the "ied" and "ies" that become "y".
+func Stem(word string) string {
+	base, y := stem(word)
+	if y {
+		return base + "y"
+	}
+	return base
+}
+
+// SameStem checks if two processed words have the same stem, see `Stem`. Unlike comparing their stems,
+// it does not allocate.
+func SameStem(a, b string) bool {
+	baseA, yA := stem(a)
+	baseB, yB := stem(b)
+	switch {
+	case yA == yB:
+		return baseA == baseB
+	case yB:
+		baseA, baseB = baseB, baseA
+	}
+	// baseA is followed by a "y"
+	return len(baseB) == len(baseA)+1 && baseB[len(baseA)] == 'y' && baseB[:len(baseA)] == baseA
+}
+
+// stem returns the stem of a processed word, without the final "y" of the "ied" and "ies" suffixes,
+// and whether the stem ends with it.
+func stem(word string) (string, bool) {
+	for _, suffix := range stemSuffixes {
+		minLength := 3
+		if suffix == "ied" || suffix == "ies" {
+			minLength = 2
+		}
+		if len(word)-len(suffix) >= minLength && word[len(word)-len(suffix):] == suffix {
+			word = word[:len(word)-len(suffix)]
+			if suffix == "ied" || suffix == "ies" {
+				return word, true
+			}
+			break
+		}
+	}
+	if len(word) > 3 && word[len(word)-1] == 'e' {
+		word = word[:len(word)-1]
+	}
+	return word, false
+}
@@ -0,0 +1,54 @@
+package text
+
+import (
+	"testing"
+)
+
+func TestStem(t *testing.T) {
+	type testCase struct {
+		word     string
+		expected string
+	}
+	cases := []testCase{
+		{word: "", expected: ""},
+		{word: "sad", expected: "sad"},
+		{word: "surprise", expected: "surpris"},
+		{word: "surprised", expected: "surpris"},
+		{word: "surprises", expected: "surpris"},
+		{word: "determine", expected: "determin"},
+		{word: "determined", expected: "determin"},
+		{word: "tried", expected: "try"},
+		{word: "happiness", expected: "happi"},
+		{word: "lonely", expected: "lon"},
+		{word: "bed", expected: "bed"},
+		{word: "red", expected: "red"}}
+
+	for _, c := range cases {
+		out := Stem(c.word)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.word)
+		}
+	}
+}
+
+func TestSameStem(t *testing.T) {
+	type testCase struct {
+		a        string
+		b        string
+		expected bool
+	}
+	cases := []testCase{
+		{a: "surprise", b: "surprised", expected: true},
+		{a: "tried", b: "try", expected: true},
+		{a: "tried", b: "tr", expected: false},
+		{a: "sad", b: "happy", expected: false}}
+
+	for _, c := range cases {
+		if out := SameStem(c.a, c.b); out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q and %q", c.expected, out, c.a, c.b)
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() { SameStem("tried", "tries") }); allocs != 0 {
+		t.Errorf("Failed: expected 0 allocations, recieved %v", allocs)
+	}
+}
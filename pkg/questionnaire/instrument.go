@@ -0,0 +1,148 @@
+// Package questionnaire scores PANAS, PANAS-X and I-PANAS-SF questionnaires, where every item is rated
+// on a 1 (very slightly or not at all) to 5 (extremely) Likert scale.
+// The instruments are defined with the item lists of the sentiment package, so that questionnaire
+// and text data share the same items and scales.
+package questionnaire
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/coderafting/panas-go/pkg/sentiment"
+)
+
+/*
+Instruments and their scales.
+*/
+
+// Scale names of the general dimension scales. The basic emotion scales of the PANAS-X are named
+// after the categories of `sentiment.PANASXCategories`.
+const (
+	PositiveAffect = "positive-affect"
+	NegativeAffect = "negative-affect"
+)
+
+// Rating bounds of the Likert scale.
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Scale is a set of items whose ratings are summed.
+type Scale struct {
+	Name  string
+	Items []string
+}
+
+// Instrument is a questionnaire, with its items and scales.
+type Instrument struct {
+	Name   string
+	Items  []string
+	Scales []Scale
+}
+
+// Scale returns the scale of the instrument with the supplied name.
+func (in Instrument) Scale(name string) (Scale, error) {
+	for _, s := range in.Scales {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return Scale{}, fmt.Errorf("instrument %s has no scale %q", in.Name, name)
+}
+
+// HasItem returns true if the item is part of the instrument.
+func (in Instrument) HasItem(item string) bool {
+	for _, i := range in.Items {
+		if i == item {
+			return true
+		}
+	}
+	return false
+}
+
+// generalScales returns the positive and negative affect scales of the items, as per their direction.
+func generalScales(items []string, categories map[string]sentiment.StateC) []Scale {
+	pa, na := Scale{Name: PositiveAffect, Items: []string{}}, Scale{Name: NegativeAffect, Items: []string{}}
+	for _, i := range items {
+		switch categories[i].Direction {
+		case sentiment.Positive:
+			pa.Items = append(pa.Items, i)
+		case sentiment.Negative:
+			na.Items = append(na.Items, i)
+		}
+	}
+	return []Scale{pa, na}
+}
+
+// panasItems are the 20 items of the PANAS, the positive ones first, each group sorted.
+var panasItems = func() []string {
+	pa, na := []string{}, []string{}
+	for i, sc := range sentiment.GeneralPosNegStates {
+		if sc.Direction == sentiment.Positive {
+			pa = append(pa, i)
+		} else {
+			na = append(na, i)
+		}
+	}
+	sort.Strings(pa)
+	sort.Strings(na)
+	return append(pa, na...)
+}()
+
+// PANAS is the 20-item Positive and Negative Affect Schedule (Watson, Clark & Tellegen, 1988),
+// with its positive affect and negative affect scales.
+var PANAS = Instrument{
+	Name:   "panas",
+	Items:  panasItems,
+	Scales: generalScales(panasItems, sentiment.GeneralPosNegStates),
+}
+
+// PANASX is the 60-item expanded form of the PANAS (Watson & Clark, 1994), with its general dimension scales,
+// made of the PANAS items, and its eleven basic emotion scales.
+var PANASX = Instrument{
+	Name:   "panas-x",
+	Items:  sentiment.PANASXItems,
+	Scales: append(generalScales(panasItems, sentiment.GeneralPosNegStates), basicScales()...),
+}
+
+// basicScales returns the basic emotion scales of the PANAS-X, in the order of their first item.
+func basicScales() []Scale {
+	res := []Scale{}
+	index := map[sentiment.Category]int{}
+	for _, i := range sentiment.PANASXItems {
+		c := sentiment.PANASXCategories[i].Category
+		if c == sentiment.General {
+			continue
+		}
+		if _, ok := index[c]; !ok {
+			index[c] = len(res)
+			res = append(res, Scale{Name: c.String(), Items: []string{}})
+		}
+		res[index[c]].Items = append(res[index[c]].Items, i)
+	}
+	return res
+}
+
+// IPANASSF is the 10-item international short form of the PANAS (Thompson, 2007),
+// with its positive affect and negative affect scales.
+var IPANASSF = Instrument{
+	Name:   "i-panas-sf",
+	Items:  sentiment.IPANASSFItems,
+	Scales: generalScales(sentiment.IPANASSFItems, sentiment.IPANASSFCategories),
+}
+
+// Instruments are the available instruments, by name.
+var Instruments = map[string]Instrument{
+	PANAS.Name:    PANAS,
+	PANASX.Name:   PANASX,
+	IPANASSF.Name: IPANASSF,
+}
+
+// InstrumentByName returns the instrument registered in `Instruments` with the supplied name.
+func InstrumentByName(name string) (Instrument, error) {
+	if in, ok := Instruments[name]; ok {
+		return in, nil
+	}
+	return Instrument{}, fmt.Errorf("unknown instrument %q", name)
+}
@@ -0,0 +1,47 @@
+package questionnaire
+
+import (
+	"testing"
+)
+
+func TestInstruments(t *testing.T) {
+	type testCase struct {
+		instrument Instrument
+		items      int
+		scales     map[string]int
+	}
+	cases := []testCase{
+		{instrument: PANAS, items: 20, scales: map[string]int{PositiveAffect: 10, NegativeAffect: 10}},
+		{instrument: IPANASSF, items: 10, scales: map[string]int{PositiveAffect: 5, NegativeAffect: 5}},
+		{instrument: PANASX, items: 60, scales: map[string]int{
+			PositiveAffect: 10, NegativeAffect: 10, "joviality": 8, "selfAssurance": 6, "attentiveness": 4,
+			"fear": 6, "hostility": 6, "guilt": 6, "sadness": 5, "shyness": 4, "fatigue": 4, "serenity": 3, "surprise": 3}}}
+
+	for _, c := range cases {
+		if len(c.instrument.Items) != c.items || len(c.instrument.Scales) != len(c.scales) {
+			t.Errorf("Failed: expected %v items and %v scales, recieved %v and %v", c.items, len(c.scales), len(c.instrument.Items), len(c.instrument.Scales))
+		}
+		for _, s := range c.instrument.Scales {
+			if len(s.Items) != c.scales[s.Name] {
+				t.Errorf("Failed: expected %v items, recieved %v in %v", c.scales[s.Name], len(s.Items), s.Name)
+			}
+			for _, i := range s.Items {
+				if !c.instrument.HasItem(i) {
+					t.Errorf("Failed: expected item %v of scale %v in %v", i, s.Name, c.instrument.Name)
+				}
+			}
+		}
+	}
+}
+
+func TestInstrumentByName(t *testing.T) {
+	if in, err := InstrumentByName("panas-x"); err != nil || in.Name != "panas-x" {
+		t.Errorf("Failed: expected panas-x, recieved %v, %v", in.Name, err)
+	}
+	if _, err := InstrumentByName("panas-y"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown instrument")
+	}
+	if _, err := PANAS.Scale("fear"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown scale")
+	}
+}
@@ -0,0 +1,121 @@
+package questionnaire
+
+import (
+	"fmt"
+	"math"
+)
+
+/*
+Published normative data, to compare the scores of a sample with the scores of the general population.
+Only the PANAS positive affect and negative affect norms are included; the PANAS-X basic emotion scale norms
+depend on the timeframe and sample of the study and are left to the caller (see `Norm`).
+*/
+
+// Norm is the normative mean and standard deviation of a scale score in a reference sample.
+type Norm struct {
+	Source string
+	// Timeframe is the timeframe of the instructions of the questionnaire, such as "moment" or "general".
+	Timeframe string
+	Scale     string
+	// N is the size of the reference sample.
+	N    int
+	Mean float64
+	SD   float64
+}
+
+// PANASNorms are the published norms of the PANAS scales.
+var PANASNorms = []Norm{
+	{Source: "Watson, Clark & Tellegen (1988)", Timeframe: "moment", Scale: PositiveAffect, N: 660, Mean: 29.7, SD: 7.9},
+	{Source: "Watson, Clark & Tellegen (1988)", Timeframe: "moment", Scale: NegativeAffect, N: 660, Mean: 14.8, SD: 5.4},
+	{Source: "Watson, Clark & Tellegen (1988)", Timeframe: "general", Scale: PositiveAffect, N: 663, Mean: 35.0, SD: 6.4},
+	{Source: "Watson, Clark & Tellegen (1988)", Timeframe: "general", Scale: NegativeAffect, N: 663, Mean: 18.1, SD: 5.9},
+	{Source: "Crawford & Henry (2004)", Timeframe: "past few weeks", Scale: PositiveAffect, N: 1003, Mean: 31.31, SD: 7.65},
+	{Source: "Crawford & Henry (2004)", Timeframe: "past few weeks", Scale: NegativeAffect, N: 1003, Mean: 16.00, SD: 5.90},
+}
+
+// FindNorm returns the first of the norms of the scale with the supplied timeframe.
+func FindNorm(norms []Norm, scale, timeframe string) (Norm, error) {
+	for _, n := range norms {
+		if n.Scale == scale && n.Timeframe == timeframe {
+			return n, nil
+		}
+	}
+	return Norm{}, fmt.Errorf("no norm for scale %q and timeframe %q", scale, timeframe)
+}
+
+// Comparison is a score compared with a norm.
+type Comparison struct {
+	Norm  Norm
+	Score float64
+	// Z is the number of standard deviations of the score from the normative mean.
+	Z float64
+	// T is the score on the T scale, with a normative mean of 50 and standard deviation of 10.
+	T float64
+	// Percentile is the percentage of the reference sample with a lower score, assuming normally distributed scores.
+	Percentile float64
+}
+
+// Compare compares a score with a norm.
+func Compare(score float64, n Norm) (Comparison, error) {
+	if n.SD <= 0 {
+		return Comparison{}, fmt.Errorf("norm standard deviation %v is not positive", n.SD)
+	}
+	if math.IsNaN(score) {
+		return Comparison{}, fmt.Errorf("score is missing")
+	}
+	z := (score - n.Mean) / n.SD
+	return Comparison{Norm: n, Score: score, Z: z, T: 50 + 10*z, Percentile: 50 * (1 + math.Erf(z/math.Sqrt2))}, nil
+}
+
+// CompareScale compares the score of a scale with its norm for the timeframe.
+func CompareScale(s ScaleScore, norms []Norm, timeframe string) (Comparison, error) {
+	n, err := FindNorm(norms, s.Scale, timeframe)
+	if err != nil {
+		return Comparison{}, err
+	}
+	return Compare(s.Score, n)
+}
+
+// SampleComparison is the mean score of a sample compared with a norm.
+type SampleComparison struct {
+	Norm Norm
+	// N, Mean and SD describe the scored responses of the sample.
+	N    int
+	Mean float64
+	SD   float64
+	// D is Cohen's d, the difference of the means in normative standard deviations.
+	D float64
+	// T is Welch's t statistic of the difference of the means, with DF degrees of freedom.
+	T  float64
+	DF float64
+}
+
+// CompareSample compares the mean of the scores of a sample with a norm. The missing (NaN) scores are skipped,
+// and at least two scores are required.
+func CompareSample(scores []float64, n Norm) (SampleComparison, error) {
+	if n.SD <= 0 || n.N < 2 {
+		return SampleComparison{}, fmt.Errorf("norm needs a positive standard deviation and a sample of at least 2")
+	}
+	res := SampleComparison{Norm: n}
+	for _, s := range scores {
+		if !math.IsNaN(s) {
+			res.N++
+			res.Mean += s
+		}
+	}
+	if res.N < 2 {
+		return SampleComparison{}, fmt.Errorf("%d scores, at least 2 are required", res.N)
+	}
+	res.Mean /= float64(res.N)
+	for _, s := range scores {
+		if !math.IsNaN(s) {
+			res.SD += (s - res.Mean) * (s - res.Mean)
+		}
+	}
+	res.SD = math.Sqrt(res.SD / float64(res.N-1))
+	res.D = (res.Mean - n.Mean) / n.SD
+	v1, v2 := res.SD*res.SD/float64(res.N), n.SD*n.SD/float64(n.N)
+	res.T = (res.Mean - n.Mean) / math.Sqrt(v1+v2)
+	res.DF = (v1 + v2) * (v1 + v2) / (v1*v1/float64(res.N-1) + v2*v2/float64(n.N-1))
+	return res, nil
+}
@@ -0,0 +1,51 @@
+package questionnaire
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	n, err := FindNorm(PANASNorms, PositiveAffect, "general")
+	if err != nil || n.Mean != 35.0 {
+		t.Fatalf("Failed: expected the general PA norm, recieved %v, %v", n, err)
+	}
+	out, err := Compare(41.4, n)
+	if err != nil || math.Abs(out.Z-1) > 1e-9 || math.Abs(out.T-60) > 1e-9 || math.Abs(out.Percentile-84.13447) > 1e-4 {
+		t.Errorf("Failed: expected z 1, T 60 and percentile 84.13, recieved %v, %v", out, err)
+	}
+	if _, err := Compare(math.NaN(), n); err == nil {
+		t.Errorf("Failed: expected an error for a missing score")
+	}
+	if _, err := Compare(10, Norm{}); err == nil {
+		t.Errorf("Failed: expected an error for a norm without standard deviation")
+	}
+	if _, err := FindNorm(PANASNorms, "fear", "general"); err == nil {
+		t.Errorf("Failed: expected an error for a scale without norm")
+	}
+}
+
+func TestCompareScale(t *testing.T) {
+	s := ScaleScore{Scale: NegativeAffect, Score: 16.00, Answered: 10, Items: 10}
+	out, err := CompareScale(s, PANASNorms, "past few weeks")
+	if err != nil || out.Z != 0 || out.Percentile != 50 {
+		t.Errorf("Failed: expected z 0, recieved %v, %v", out, err)
+	}
+}
+
+func TestCompareSample(t *testing.T) {
+	n := Norm{Scale: PositiveAffect, N: 100, Mean: 30, SD: 5}
+	out, err := CompareSample([]float64{30, 35, math.NaN(), 40}, n)
+	if err != nil || out.N != 3 || out.Mean != 35 || out.SD != 5 || out.D != 1 {
+		t.Fatalf("Failed: expected n 3, mean 35, sd 5 and d 1, recieved %v, %v", out, err)
+	}
+	if math.Abs(out.T-5/math.Sqrt(25.0/3+0.25)) > 1e-9 {
+		t.Errorf("Failed: expected %v, recieved %v", 5/math.Sqrt(25.0/3+0.25), out.T)
+	}
+	if _, err := CompareSample([]float64{30}, n); err == nil {
+		t.Errorf("Failed: expected an error for a single score")
+	}
+	if _, err := CompareSample([]float64{30, 31}, Norm{SD: 5, N: 1}); err == nil {
+		t.Errorf("Failed: expected an error for an invalid norm")
+	}
+}
@@ -0,0 +1,113 @@
+package questionnaire
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+/*
+Validation and scoring of the responses. A scale score is the sum of the ratings of its items;
+when some items are missing, the score is prorated from the mean of the answered items,
+as long as enough of them were answered.
+*/
+
+// Response are the ratings of a respondent, by item. Unanswered items are absent.
+type Response map[string]int
+
+// Validate checks that every rated item is part of the instrument and that every rating is within
+// `MinRating` and `MaxRating`.
+func (in Instrument) Validate(r Response) error {
+	items := make([]string, 0, len(r))
+	for i := range r {
+		items = append(items, i)
+	}
+	sort.Strings(items)
+	for _, i := range items {
+		if !in.HasItem(i) {
+			return fmt.Errorf("item %q is not part of instrument %s", i, in.Name)
+		}
+		if r[i] < MinRating || r[i] > MaxRating {
+			return fmt.Errorf("rating %d of item %q is out of range %d-%d", r[i], i, MinRating, MaxRating)
+		}
+	}
+	return nil
+}
+
+// Proration decides when a scale with missing items is scored.
+type Proration struct {
+	// MinAnswered is the minimum fraction of the items of a scale that must be answered for it to be scored.
+	// The zero value requires every item.
+	MinAnswered float64
+}
+
+// DefaultProration scores the scales with at least 80% of their items answered, such as 8 out of 10.
+var DefaultProration = Proration{MinAnswered: 0.8}
+
+// ScaleScore is the score of a scale for a response.
+type ScaleScore struct {
+	Scale string
+	// Score is the sum of the ratings of the items, prorated to all the items of the scale if some are missing.
+	// It is NaN if the scale is not scored.
+	Score float64
+	// Answered is the number of answered items, out of Items.
+	Answered int
+	Items    int
+	// Prorated is true if the score was prorated from the answered items.
+	Prorated bool
+}
+
+// Scored returns true if enough items were answered for the scale to be scored.
+func (s ScaleScore) Scored() bool {
+	return !math.IsNaN(s.Score)
+}
+
+// Mean returns the mean rating of the answered items of the scale, NaN if the scale is not scored.
+func (s ScaleScore) Mean() float64 {
+	return s.Score / float64(s.Items)
+}
+
+// Score validates the response and returns the score of every scale of the instrument, in the order of the scales.
+func (in Instrument) Score(r Response, p Proration) ([]ScaleScore, error) {
+	if err := in.Validate(r); err != nil {
+		return nil, err
+	}
+	res := []ScaleScore{}
+	for _, s := range in.Scales {
+		res = append(res, s.score(r, p))
+	}
+	return res, nil
+}
+
+// ScoreScale validates the response and returns the score of the scale of the instrument with the supplied name.
+func (in Instrument) ScoreScale(r Response, scale string, p Proration) (ScaleScore, error) {
+	s, err := in.Scale(scale)
+	if err != nil {
+		return ScaleScore{}, err
+	}
+	if err := in.Validate(r); err != nil {
+		return ScaleScore{}, err
+	}
+	return s.score(r, p), nil
+}
+
+func (s Scale) score(r Response, p Proration) ScaleScore {
+	res := ScaleScore{Scale: s.Name, Items: len(s.Items)}
+	sum := 0
+	for _, i := range s.Items {
+		if rating, ok := r[i]; ok {
+			sum += rating
+			res.Answered++
+		}
+	}
+	switch {
+	case res.Answered == 0 || float64(res.Answered)+1e-9 < p.MinAnswered*float64(res.Items) || (p.MinAnswered == 0 && res.Answered < res.Items):
+		res.Score = math.NaN()
+	case res.Answered < res.Items:
+		res.Score = float64(sum) / float64(res.Answered) * float64(res.Items)
+		res.Prorated = true
+	default:
+		res.Score = float64(sum)
+	}
+	return res
+}
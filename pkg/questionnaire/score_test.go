@@ -0,0 +1,96 @@
+package questionnaire
+
+import (
+	"math"
+	"testing"
+)
+
+// fullResponse rates every item of the instrument with the rating.
+func fullResponse(in Instrument, rating int) Response {
+	r := Response{}
+	for _, i := range in.Items {
+		r[i] = rating
+	}
+	return r
+}
+
+func TestValidate(t *testing.T) {
+	cases := []Response{{"happy": 3}, {"upset": 0}, {"upset": 6}}
+	for _, r := range cases {
+		if err := PANAS.Validate(r); err == nil {
+			t.Errorf("Failed: expected an error for %v", r)
+		}
+	}
+	if err := PANAS.Validate(Response{"upset": 1, "active": 5}); err != nil {
+		t.Errorf("Failed: expected no error, recieved %v", err)
+	}
+	if _, err := PANAS.Score(Response{"upset": 9}, DefaultProration); err == nil {
+		t.Errorf("Failed: expected an error for an invalid response")
+	}
+}
+
+func TestScore(t *testing.T) {
+	r := fullResponse(PANAS, 2)
+	r["active"] = 4
+	out, err := PANAS.Score(r, DefaultProration)
+	if err != nil || len(out) != 2 {
+		t.Fatalf("Failed: expected 2 scores, recieved %v, %v", out, err)
+	}
+	expected := []ScaleScore{{Scale: PositiveAffect, Score: 22, Answered: 10, Items: 10}, {Scale: NegativeAffect, Score: 20, Answered: 10, Items: 10}}
+	for i := range out {
+		if out[i] != expected[i] {
+			t.Errorf("Failed: expected %v, recieved %v", expected[i], out[i])
+		}
+	}
+	if out[0].Mean() != 2.2 {
+		t.Errorf("Failed: expected 2.2, recieved %v", out[0].Mean())
+	}
+}
+
+func TestScoreProration(t *testing.T) {
+	type testCase struct {
+		missing   []string
+		proration Proration
+		scored    bool
+		score     float64
+	}
+	cases := []testCase{
+		{missing: []string{}, proration: Proration{}, scored: true, score: 30},
+		{missing: []string{"active"}, proration: Proration{}, scored: false},
+		{missing: []string{"active"}, proration: DefaultProration, scored: true, score: 30},
+		{missing: []string{"active", "alert"}, proration: DefaultProration, scored: true, score: 30},
+		{missing: []string{"active", "alert", "attentive"}, proration: DefaultProration, scored: false},
+		{missing: []string{"active", "alert", "attentive"}, proration: Proration{MinAnswered: 0.5}, scored: true, score: 30}}
+
+	for _, c := range cases {
+		r := fullResponse(PANAS, 3)
+		for _, i := range c.missing {
+			delete(r, i)
+		}
+		out, err := PANAS.ScoreScale(r, PositiveAffect, c.proration)
+		if err != nil || out.Scored() != c.scored || out.Prorated != (c.scored && len(c.missing) > 0) {
+			t.Errorf("Failed: expected %v, recieved %v, %v for %v", c.scored, out, err, c.missing)
+			continue
+		}
+		if c.scored && math.Abs(out.Score-c.score) > 1e-9 {
+			t.Errorf("Failed: expected %v, recieved %v", c.score, out.Score)
+		}
+		if out.Answered != 10-len(c.missing) {
+			t.Errorf("Failed: expected %v, recieved %v", 10-len(c.missing), out.Answered)
+		}
+	}
+	out, _ := PANAS.ScoreScale(Response{}, PositiveAffect, Proration{MinAnswered: 0})
+	if out.Scored() {
+		t.Errorf("Failed: expected an empty response not to be scored")
+	}
+}
+
+func TestScorePANASX(t *testing.T) {
+	out, err := PANASX.ScoreScale(fullResponse(PANASX, 5), "serenity", Proration{})
+	if err != nil || out.Score != 15 {
+		t.Errorf("Failed: expected 15, recieved %v, %v", out, err)
+	}
+	if _, err := PANASX.ScoreScale(Response{}, "anxiety", Proration{}); err == nil {
+		t.Errorf("Failed: expected an error for an unknown scale")
+	}
+}
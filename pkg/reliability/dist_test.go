@@ -0,0 +1,33 @@
+package reliability
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFQuantile(t *testing.T) {
+	type testCase struct {
+		p        float64
+		d1       float64
+		d2       float64
+		expected float64
+	}
+	// values of the F distribution tables
+	cases := []testCase{
+		{p: 0.95, d1: 5, d2: 10, expected: 3.3258},
+		{p: 0.975, d1: 10, d2: 20, expected: 2.7737},
+		{p: 0.95, d1: 1, d2: 1, expected: 161.4476},
+		{p: 0.05, d1: 5, d2: 10, expected: 1 / 4.7351}}
+
+	for _, c := range cases {
+		if out := fQuantile(c.p, c.d1, c.d2); math.Abs(out-c.expected) > 1e-3*c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %v", c.expected, out, c)
+		}
+	}
+}
+
+func TestNormalQuantile(t *testing.T) {
+	if out := normalQuantile(0.975); math.Abs(out-1.959964) > 1e-6 {
+		t.Errorf("Failed: expected 1.959964, recieved %v", out)
+	}
+}
@@ -0,0 +1,104 @@
+// Package reliability computes psychometric reliability statistics (Cronbach's alpha, McDonald's omega,
+// item-total correlations and split-half reliability) for the PANAS scales, from questionnaire responses
+// or from the states detected in texts.
+package reliability
+
+import (
+	"github.com/coderafting/panas-go/pkg/questionnaire"
+	"github.com/coderafting/panas-go/pkg/sentiment"
+)
+
+/*
+Data matrices: a row per respondent or text, a column per item.
+*/
+
+// Matrix holds the scores of a set of items, a row per observation and a column per item.
+type Matrix struct {
+	Items []string
+	Rows  [][]float64
+}
+
+// Column returns the scores of the item at index j.
+func (m Matrix) Column(j int) []float64 {
+	return column(m.Rows, j)
+}
+
+// Select returns the matrix of the supplied items, in their order. Items that are not in the matrix are skipped.
+func (m Matrix) Select(items []string) Matrix {
+	index := map[string]int{}
+	for j, i := range m.Items {
+		index[i] = j
+	}
+	res := Matrix{Items: []string{}, Rows: make([][]float64, len(m.Rows))}
+	cols := []int{}
+	for _, i := range items {
+		if j, ok := index[i]; ok {
+			res.Items = append(res.Items, i)
+			cols = append(cols, j)
+		}
+	}
+	for r, row := range m.Rows {
+		res.Rows[r] = make([]float64, len(cols))
+		for c, j := range cols {
+			res.Rows[r][c] = row[j]
+		}
+	}
+	return res
+}
+
+// FromResponses returns the matrix of the ratings of the items. The responses that miss one of the items
+// are dropped (listwise deletion).
+func FromResponses(responses []questionnaire.Response, items []string) Matrix {
+	res := Matrix{Items: items, Rows: [][]float64{}}
+	for _, r := range responses {
+		row := make([]float64, len(items))
+		complete := true
+		for j, i := range items {
+			rating, ok := r[i]
+			if !ok {
+				complete = false
+				break
+			}
+			row[j] = float64(rating)
+		}
+		if complete {
+			res.Rows = append(res.Rows, row)
+		}
+	}
+	return res
+}
+
+// FromTexts returns the indicator matrix of the states in the texts: 1 if the state is counted by the analyzer
+// in the text, 0 otherwise.
+func FromTexts(texts []string, an sentiment.Analyzer, states []string) Matrix {
+	res := Matrix{Items: states, Rows: [][]float64{}}
+	for _, t := range texts {
+		row := make([]float64, len(states))
+		found := an.States(t)
+		for j, s := range states {
+			for _, f := range found {
+				if f == s {
+					row[j] = 1
+					break
+				}
+			}
+		}
+		res.Rows = append(res.Rows, row)
+	}
+	return res
+}
+
+// TextScales returns the subscales of a lexicon: the states of every category, in the order of the lexicon.
+func TextScales(lexicon *sentiment.Lexicon) []questionnaire.Scale {
+	res := []questionnaire.Scale{}
+	index := map[sentiment.Category]int{}
+	for _, s := range lexicon.States {
+		c := lexicon.Categories[s].Category
+		if _, ok := index[c]; !ok {
+			index[c] = len(res)
+			res = append(res, questionnaire.Scale{Name: c.String(), Items: []string{}})
+		}
+		res[index[c]].Items = append(res[index[c]].Items, s)
+	}
+	return res
+}
@@ -0,0 +1,52 @@
+package reliability
+
+import (
+	"testing"
+
+	"github.com/coderafting/panas-go/pkg/questionnaire"
+	"github.com/coderafting/panas-go/pkg/sentiment"
+)
+
+func TestFromResponses(t *testing.T) {
+	responses := []questionnaire.Response{{"alert": 3, "active": 4}, {"alert": 2}, {"alert": 5, "active": 1, "upset": 2}}
+	out := FromResponses(responses, []string{"alert", "active"})
+	expected := [][]float64{{3, 4}, {5, 1}}
+	if len(out.Rows) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v", expected, out.Rows)
+	}
+	for i := range expected {
+		for j := range expected[i] {
+			if out.Rows[i][j] != expected[i][j] {
+				t.Errorf("Failed: expected %v, recieved %v", expected[i][j], out.Rows[i][j])
+			}
+		}
+	}
+}
+
+func TestFromTexts(t *testing.T) {
+	texts := []string{"I am happy and cheerful", "I am sad", `he wrote "I am happy"`}
+	out := FromTexts(texts, sentiment.Analyzer{}, []string{"happy", "cheerful", "sad"})
+	expected := [][]float64{{1, 1, 0}, {0, 0, 1}, {0, 0, 0}}
+	for i := range expected {
+		for j := range expected[i] {
+			if out.Rows[i][j] != expected[i][j] {
+				t.Errorf("Failed: expected %v, recieved %v in %q", expected[i], out.Rows[i], texts[i])
+				break
+			}
+		}
+	}
+}
+
+func TestTextScales(t *testing.T) {
+	out := TextScales(sentiment.PANASt)
+	if len(out) != 11 || out[0].Name != "joviality" || len(out[0].Items) != 8 || out[10].Name != "surprise" {
+		t.Errorf("Failed: expected the 11 PANAS-t categories, recieved %v", out)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	out := testMatrix.Select([]string{"attentive", "unknown", "alert"})
+	if len(out.Items) != 2 || out.Items[0] != "attentive" || out.Rows[0][0] != 3 || out.Rows[0][1] != 4 {
+		t.Errorf("Failed: expected attentive and alert, recieved %v", out)
+	}
+}
@@ -0,0 +1,437 @@
+package reliability
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/coderafting/panas-go/pkg/questionnaire"
+)
+
+/*
+Reliability statistics. Cronbach's alpha comes with the Feldt (1965) confidence interval,
+McDonald's omega (total, from a one-factor principal axis solution) with a percentile bootstrap interval,
+and the Spearman-Brown corrected odd-even split-half reliability with a Fisher z interval.
+*/
+
+// Interval is a confidence interval.
+type Interval struct {
+	Lower float64
+	Upper float64
+}
+
+// Estimate is a statistic with its confidence interval.
+type Estimate struct {
+	Value float64
+	CI    Interval
+}
+
+// Options are the settings of the confidence intervals.
+type Options struct {
+	// Confidence is the confidence level of the intervals. It defaults to 0.95.
+	Confidence float64
+	// Resamples is the number of bootstrap resamples of the omega interval. It defaults to 1000.
+	Resamples int
+	// Seed is the seed of the bootstrap resampling, so that the intervals are reproducible.
+	Seed int64
+}
+
+func (o Options) confidence() float64 {
+	if o.Confidence <= 0 || o.Confidence >= 1 {
+		return 0.95
+	}
+	return o.Confidence
+}
+
+func (o Options) resamples() int {
+	if o.Resamples <= 0 {
+		return 1000
+	}
+	return o.Resamples
+}
+
+// check returns an error if the matrix has fewer than 2 items or 3 observations, or rows of the wrong length.
+func check(m Matrix) error {
+	if len(m.Items) < 2 {
+		return fmt.Errorf("%d items, at least 2 are required", len(m.Items))
+	}
+	if len(m.Rows) < 3 {
+		return fmt.Errorf("%d observations, at least 3 are required", len(m.Rows))
+	}
+	for i, r := range m.Rows {
+		if len(r) != len(m.Items) {
+			return fmt.Errorf("row %d has %d scores for %d items", i, len(r), len(m.Items))
+		}
+	}
+	return nil
+}
+
+// CronbachAlpha returns Cronbach's alpha of the items of the matrix, with the Feldt confidence interval.
+func CronbachAlpha(m Matrix, o Options) (Estimate, error) {
+	if err := check(m); err != nil {
+		return Estimate{}, err
+	}
+	a, err := alpha(m.Rows, len(m.Items))
+	if err != nil {
+		return Estimate{}, err
+	}
+	g := 1 - o.confidence()
+	n, k := float64(len(m.Rows)), float64(len(m.Items))
+	d1, d2 := n-1, (n-1)*(k-1)
+	return Estimate{Value: a, CI: Interval{
+		Lower: 1 - (1-a)*fQuantile(1-g/2, d1, d2),
+		Upper: 1 - (1-a)*fQuantile(g/2, d1, d2),
+	}}, nil
+}
+
+func alpha(rows [][]float64, k int) (float64, error) {
+	itemVariances := 0.0
+	for j := 0; j < k; j++ {
+		itemVariances += variance(column(rows, j))
+	}
+	totalVariance := variance(totals(rows, -1))
+	if totalVariance == 0 {
+		return 0, fmt.Errorf("the total scores have no variance")
+	}
+	return float64(k) / float64(k-1) * (1 - itemVariances/totalVariance), nil
+}
+
+// McDonaldOmega returns McDonald's omega total of the items of the matrix, computed from the loadings
+// of a one-factor principal axis solution, with a percentile bootstrap confidence interval.
+func McDonaldOmega(m Matrix, o Options) (Estimate, error) {
+	if err := check(m); err != nil {
+		return Estimate{}, err
+	}
+	w, err := omega(m.Rows, len(m.Items))
+	if err != nil {
+		return Estimate{}, err
+	}
+	rnd := rand.New(rand.NewSource(o.Seed))
+	values := []float64{}
+	sample := make([][]float64, len(m.Rows))
+	for b := 0; b < o.resamples(); b++ {
+		for i := range sample {
+			sample[i] = m.Rows[rnd.Intn(len(m.Rows))]
+		}
+		if v, err := omega(sample, len(m.Items)); err == nil {
+			values = append(values, v)
+		}
+	}
+	if len(values) == 0 {
+		return Estimate{}, fmt.Errorf("no bootstrap resample could be analyzed")
+	}
+	sort.Float64s(values)
+	g := 1 - o.confidence()
+	return Estimate{Value: w, CI: Interval{Lower: quantile(values, g/2), Upper: quantile(values, 1-g/2)}}, nil
+}
+
+func omega(rows [][]float64, k int) (float64, error) {
+	loadings, err := oneFactorLoadings(correlations(rows, k))
+	if err != nil {
+		return 0, err
+	}
+	sum, uniqueness := 0.0, 0.0
+	for _, l := range loadings {
+		sum += l
+		uniqueness += 1 - l*l
+	}
+	return sum * sum / (sum*sum + uniqueness), nil
+}
+
+// oneFactorLoadings returns the loadings of the one-factor principal axis solution of the correlation matrix.
+func oneFactorLoadings(r [][]float64) ([]float64, error) {
+	k := len(r)
+	communalities := make([]float64, k)
+	for i := range r {
+		for j := range r[i] {
+			if math.IsNaN(r[i][j]) {
+				return nil, fmt.Errorf("an item has no variance")
+			}
+			if i != j && math.Abs(r[i][j]) > communalities[i] {
+				communalities[i] = math.Abs(r[i][j])
+			}
+		}
+	}
+	loadings := make([]float64, k)
+	for iter := 0; iter < 100; iter++ {
+		reduced := make([][]float64, k)
+		for i := range r {
+			reduced[i] = append([]float64{}, r[i]...)
+			reduced[i][i] = communalities[i]
+		}
+		value, vector := largestEigen(reduced)
+		if value <= 0 {
+			return nil, fmt.Errorf("the items share no common variance")
+		}
+		change := 0.0
+		for i := range loadings {
+			loadings[i] = vector[i] * math.Sqrt(value)
+			h := math.Min(loadings[i]*loadings[i], 0.995)
+			change = math.Max(change, math.Abs(h-communalities[i]))
+			communalities[i] = h
+		}
+		if change < 1e-6 {
+			break
+		}
+	}
+	sum := 0.0
+	for i, l := range loadings {
+		// Heywood cases are bounded like the communalities
+		loadings[i] = math.Copysign(math.Min(math.Abs(l), math.Sqrt(0.995)), l)
+		sum += l
+	}
+	if sum < 0 {
+		for i := range loadings {
+			loadings[i] = -loadings[i]
+		}
+	}
+	return loadings, nil
+}
+
+// largestEigen returns the largest eigenvalue of the symmetric matrix, with its unit eigenvector, by power iteration.
+func largestEigen(a [][]float64) (float64, []float64) {
+	k := len(a)
+	v := make([]float64, k)
+	for i := range v {
+		v[i] = 1 / math.Sqrt(float64(k))
+	}
+	value := 0.0
+	for iter := 0; iter < 1000; iter++ {
+		w := make([]float64, k)
+		for i := range a {
+			for j := range a[i] {
+				w[i] += a[i][j] * v[j]
+			}
+		}
+		norm := 0.0
+		for _, x := range w {
+			norm += x * x
+		}
+		norm = math.Sqrt(norm)
+		if norm == 0 {
+			return 0, v
+		}
+		for i := range w {
+			w[i] /= norm
+		}
+		converged := math.Abs(norm-value) < 1e-12
+		v, value = w, norm
+		if converged {
+			break
+		}
+	}
+	// the Rayleigh quotient gives the sign of the eigenvalue
+	rayleigh := 0.0
+	for i := range a {
+		for j := range a[i] {
+			rayleigh += v[i] * a[i][j] * v[j]
+		}
+	}
+	return rayleigh, v
+}
+
+// ItemStats are the statistics of an item within its scale.
+type ItemStats struct {
+	Item string
+	Mean float64
+	SD   float64
+	// CorrectedTotal is the correlation of the item with the total of the other items of the scale.
+	CorrectedTotal float64
+	// AlphaIfDeleted is Cronbach's alpha of the other items of the scale. It is NaN for a scale of two items.
+	AlphaIfDeleted float64
+}
+
+// ItemTotal returns the statistics of every item of the matrix.
+func ItemTotal(m Matrix) ([]ItemStats, error) {
+	if err := check(m); err != nil {
+		return nil, err
+	}
+	res := []ItemStats{}
+	for j, item := range m.Items {
+		col := m.Column(j)
+		s := ItemStats{Item: item, Mean: mean(col), SD: math.Sqrt(variance(col)), AlphaIfDeleted: math.NaN()}
+		s.CorrectedTotal = pearson(col, totals(m.Rows, j))
+		if len(m.Items) > 2 {
+			rest := make([][]float64, len(m.Rows))
+			for i, r := range m.Rows {
+				rest[i] = append(append([]float64{}, r[:j]...), r[j+1:]...)
+			}
+			if a, err := alpha(rest, len(m.Items)-1); err == nil {
+				s.AlphaIfDeleted = a
+			}
+		}
+		res = append(res, s)
+	}
+	return res, nil
+}
+
+// SplitHalfResult is the split-half reliability of a scale.
+type SplitHalfResult struct {
+	// R is the correlation of the totals of the odd and even items.
+	R float64
+	// SpearmanBrown is the reliability of the full scale, 2R / (1 + R), with the Fisher z confidence interval of R
+	// carried through the Spearman-Brown correction.
+	SpearmanBrown Estimate
+}
+
+// SplitHalf returns the odd-even split-half reliability of the items of the matrix.
+func SplitHalf(m Matrix, o Options) (SplitHalfResult, error) {
+	if err := check(m); err != nil {
+		return SplitHalfResult{}, err
+	}
+	odd, even := make([]float64, len(m.Rows)), make([]float64, len(m.Rows))
+	for i, r := range m.Rows {
+		for j, x := range r {
+			if j%2 == 0 {
+				odd[i] += x
+			} else {
+				even[i] += x
+			}
+		}
+	}
+	r := pearson(odd, even)
+	if math.IsNaN(r) {
+		return SplitHalfResult{}, fmt.Errorf("a half of the scale has no variance")
+	}
+	spearmanBrown := func(r float64) float64 { return 2 * r / (1 + r) }
+	res := SplitHalfResult{R: r, SpearmanBrown: Estimate{Value: spearmanBrown(r)}}
+	if len(m.Rows) > 3 && math.Abs(r) < 1 {
+		z, se := math.Atanh(r), 1/math.Sqrt(float64(len(m.Rows)-3))
+		q := normalQuantile(1 - (1-o.confidence())/2)
+		res.SpearmanBrown.CI = Interval{Lower: spearmanBrown(math.Tanh(z - q*se)), Upper: spearmanBrown(math.Tanh(z + q*se))}
+	} else {
+		res.SpearmanBrown.CI = Interval{Lower: res.SpearmanBrown.Value, Upper: res.SpearmanBrown.Value}
+	}
+	return res, nil
+}
+
+// ScaleReliability are the reliability statistics of a scale.
+type ScaleReliability struct {
+	Scale string
+	// N is the number of observations.
+	N         int
+	Alpha     Estimate
+	Omega     Estimate
+	SplitHalf SplitHalfResult
+	Items     []ItemStats
+	// Err is the reason why some statistics could not be computed, nil if they all were.
+	Err error
+}
+
+// Scales returns the reliability statistics of every scale, with the items of the matrix.
+// The scales with fewer than two items in the matrix are skipped.
+func Scales(m Matrix, scales []questionnaire.Scale, o Options) []ScaleReliability {
+	res := []ScaleReliability{}
+	for _, s := range scales {
+		sm := m.Select(s.Items)
+		if len(sm.Items) < 2 {
+			continue
+		}
+		r := ScaleReliability{Scale: s.Name, N: len(sm.Rows)}
+		var errs []error
+		var err error
+		if r.Alpha, err = CronbachAlpha(sm, o); err != nil {
+			errs = append(errs, fmt.Errorf("alpha: %v", err))
+		}
+		if r.Omega, err = McDonaldOmega(sm, o); err != nil {
+			errs = append(errs, fmt.Errorf("omega: %v", err))
+		}
+		if r.SplitHalf, err = SplitHalf(sm, o); err != nil {
+			errs = append(errs, fmt.Errorf("split-half: %v", err))
+		}
+		if r.Items, err = ItemTotal(sm); err != nil {
+			errs = append(errs, fmt.Errorf("item-total: %v", err))
+		}
+		if len(errs) > 0 {
+			r.Err = fmt.Errorf("scale %s: %v", s.Name, errs)
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func column(rows [][]float64, j int) []float64 {
+	res := make([]float64, len(rows))
+	for i, r := range rows {
+		res[i] = r[j]
+	}
+	return res
+}
+
+// totals returns the total of every row, without the item at index skip (none if skip is negative).
+func totals(rows [][]float64, skip int) []float64 {
+	res := make([]float64, len(rows))
+	for i, r := range rows {
+		for j, x := range r {
+			if j != skip {
+				res[i] += x
+			}
+		}
+	}
+	return res
+}
+
+func mean(xs []float64) float64 {
+	sum := 0.0
+	for _, x := range xs {
+		sum += x
+	}
+	return sum / float64(len(xs))
+}
+
+// variance returns the sample variance.
+func variance(xs []float64) float64 {
+	m, sum := mean(xs), 0.0
+	for _, x := range xs {
+		sum += (x - m) * (x - m)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// pearson returns the correlation of xs and ys, NaN if one of them has no variance.
+func pearson(xs, ys []float64) float64 {
+	mx, my := mean(xs), mean(ys)
+	sxy, sxx, syy := 0.0, 0.0, 0.0
+	for i := range xs {
+		sxy += (xs[i] - mx) * (ys[i] - my)
+		sxx += (xs[i] - mx) * (xs[i] - mx)
+		syy += (ys[i] - my) * (ys[i] - my)
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+func correlations(rows [][]float64, k int) [][]float64 {
+	cols := make([][]float64, k)
+	for j := range cols {
+		cols[j] = column(rows, j)
+	}
+	res := make([][]float64, k)
+	for i := range res {
+		res[i] = make([]float64, k)
+		for j := range res[i] {
+			if i == j {
+				res[i][j] = 1
+				if variance(cols[i]) == 0 {
+					res[i][j] = math.NaN()
+				}
+			} else {
+				res[i][j] = pearson(cols[i], cols[j])
+			}
+		}
+	}
+	return res
+}
+
+// quantile returns the quantile of sorted values at p, with linear interpolation.
+func quantile(sorted []float64, p float64) float64 {
+	pos := p * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	if lo+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[lo] + (pos-float64(lo))*(sorted[lo+1]-sorted[lo])
+}
@@ -0,0 +1,123 @@
+package reliability
+
+import (
+	"math"
+	"testing"
+
+	"github.com/coderafting/panas-go/pkg/questionnaire"
+)
+
+var testMatrix = Matrix{
+	Items: []string{"alert", "inspired", "determined", "attentive"},
+	Rows: [][]float64{
+		{4, 5, 4, 3}, {3, 4, 3, 3}, {5, 5, 4, 5}, {2, 3, 2, 1}, {4, 4, 5, 4},
+		{1, 2, 1, 2}, {3, 3, 4, 3}, {5, 4, 5, 5}, {2, 2, 3, 2}, {4, 5, 4, 4}}}
+
+func TestCronbachAlpha(t *testing.T) {
+	out, err := CronbachAlpha(testMatrix, Options{})
+	if err != nil || math.Abs(out.Value-0.9409389) > 1e-6 {
+		t.Fatalf("Failed: expected 0.9409389, recieved %v, %v", out, err)
+	}
+	lower := 1 - (1-out.Value)*fQuantile(0.975, 9, 27)
+	upper := 1 - (1-out.Value)*fQuantile(0.025, 9, 27)
+	if out.CI.Lower != lower || out.CI.Upper != upper || !(lower < out.Value && out.Value < upper && upper < 1) {
+		t.Errorf("Failed: expected [%v, %v], recieved %v", lower, upper, out.CI)
+	}
+	narrow, _ := CronbachAlpha(testMatrix, Options{Confidence: 0.5})
+	if narrow.CI.Lower <= out.CI.Lower || narrow.CI.Upper >= out.CI.Upper {
+		t.Errorf("Failed: expected %v within %v", narrow.CI, out.CI)
+	}
+}
+
+func TestMcDonaldOmega(t *testing.T) {
+	out, err := McDonaldOmega(testMatrix, Options{Resamples: 200, Seed: 1})
+	if err != nil || out.Value < 0.9 || out.Value > 1 || out.CI.Lower > out.Value || out.CI.Upper < out.Value {
+		t.Fatalf("Failed: expected an omega close to alpha, recieved %v, %v", out, err)
+	}
+	again, _ := McDonaldOmega(testMatrix, Options{Resamples: 200, Seed: 1})
+	if again != out {
+		t.Errorf("Failed: expected %v, recieved %v", out, again)
+	}
+	// parallel items with equal correlations r have an omega equal to k r / (1 + (k - 1) r)
+	parallel := Matrix{Items: []string{"a", "b", "c"}, Rows: [][]float64{}}
+	for _, f := range []float64{0, 4} {
+		for _, p := range [][]float64{{1, 2, 3}, {2, 3, 1}, {3, 1, 2}, {1, 3, 2}, {2, 1, 3}, {3, 2, 1}} {
+			parallel.Rows = append(parallel.Rows, []float64{p[0] + f, p[1] + f, p[2] + f})
+		}
+	}
+	r := pearson(parallel.Column(0), parallel.Column(1))
+	out, err = McDonaldOmega(parallel, Options{Resamples: 10})
+	if expected := 3 * r / (1 + 2*r); err != nil || math.Abs(out.Value-expected) > 1e-4 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out.Value, err)
+	}
+}
+
+func TestItemTotal(t *testing.T) {
+	out, err := ItemTotal(testMatrix)
+	expected := []float64{0.9802123, 0.7683220, 0.8304548, 0.8670798}
+	if err != nil || len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	for i := range out {
+		if math.Abs(out[i].CorrectedTotal-expected[i]) > 1e-6 {
+			t.Errorf("Failed: expected %v, recieved %v", expected[i], out[i].CorrectedTotal)
+		}
+		rest, _ := CronbachAlpha(Matrix{Items: []string{"x", "y", "z"}, Rows: without(testMatrix.Rows, i)}, Options{})
+		if math.Abs(out[i].AlphaIfDeleted-rest.Value) > 1e-12 {
+			t.Errorf("Failed: expected %v, recieved %v", rest.Value, out[i].AlphaIfDeleted)
+		}
+	}
+	if out[0].Item != "alert" || out[0].Mean != 3.3 {
+		t.Errorf("Failed: expected alert with mean 3.3, recieved %v", out[0])
+	}
+}
+
+func without(rows [][]float64, j int) [][]float64 {
+	res := [][]float64{}
+	for _, r := range rows {
+		res = append(res, append(append([]float64{}, r[:j]...), r[j+1:]...))
+	}
+	return res
+}
+
+func TestSplitHalf(t *testing.T) {
+	out, err := SplitHalf(testMatrix, Options{})
+	if err != nil || math.Abs(out.R-0.9004263) > 1e-6 || math.Abs(out.SpearmanBrown.Value-0.9476046) > 1e-6 {
+		t.Fatalf("Failed: expected 0.9004263 and 0.9476046, recieved %v, %v", out, err)
+	}
+	if !(out.SpearmanBrown.CI.Lower < out.SpearmanBrown.Value && out.SpearmanBrown.Value < out.SpearmanBrown.CI.Upper) {
+		t.Errorf("Failed: expected the interval %v to contain %v", out.SpearmanBrown.CI, out.SpearmanBrown.Value)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	cases := []Matrix{
+		{Items: []string{"a"}, Rows: [][]float64{{1}, {2}, {3}}},
+		{Items: []string{"a", "b"}, Rows: [][]float64{{1, 2}, {2, 1}}},
+		{Items: []string{"a", "b"}, Rows: [][]float64{{1, 2}, {2}, {3, 3}}},
+		{Items: []string{"a", "b"}, Rows: [][]float64{{1, 1}, {1, 1}, {1, 1}}}}
+	for _, m := range cases {
+		if _, err := CronbachAlpha(m, Options{}); err == nil {
+			t.Errorf("Failed: expected an error for %v", m)
+		}
+		if _, err := McDonaldOmega(m, Options{Resamples: 10}); err == nil {
+			t.Errorf("Failed: expected an error for %v", m)
+		}
+		if _, err := SplitHalf(m, Options{}); err == nil {
+			t.Errorf("Failed: expected an error for %v", m)
+		}
+	}
+}
+
+func TestScales(t *testing.T) {
+	scales := []questionnaire.Scale{
+		{Name: "attention", Items: []string{"alert", "attentive", "determined"}},
+		{Name: "single", Items: []string{"inspired", "upset"}}}
+	out := Scales(testMatrix, scales, Options{Resamples: 50})
+	if len(out) != 1 || out[0].Scale != "attention" || out[0].N != 10 || out[0].Err != nil || len(out[0].Items) != 3 {
+		t.Fatalf("Failed: expected the attention scale, recieved %v", out)
+	}
+	if out[0].Items[1].Item != "attentive" {
+		t.Errorf("Failed: expected attentive, recieved %v", out[0].Items[1].Item)
+	}
+}
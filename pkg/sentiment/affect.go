@@ -0,0 +1,138 @@
+package sentiment
+
+import (
+	"fmt"
+	"sort"
+)
+
+/*
+Positive Affect and Negative Affect, as per the original PANAS (Watson, Clark & Tellegen, 1988).
+The 20 general items of `GeneralPosNegStates` are detected in the texts, like the PANAS-t states,
+and scored separately from the 11 categories: the Positive Affect (PA) of a text is the number of distinct
+positive items it expresses, its Negative Affect (NA) the number of distinct negative ones.
+*/
+
+// Affect is the Positive Affect and Negative Affect of a text.
+type Affect struct {
+	// Positive is the number of distinct positive items of the text.
+	Positive int
+	// Negative is the number of distinct negative items of the text.
+	Negative int
+	// PositiveItems and NegativeItems are the items of the text, in the order they first appear.
+	PositiveItems []string
+	NegativeItems []string
+}
+
+// Balance returns the affect-balance index of the text, (PA - NA) / (PA + NA), from -1 for a text that
+// only expresses negative affect to 1 for a text that only expresses positive affect. It is 0 if the text
+// expresses neither.
+func (a Affect) Balance() float64 {
+	return balance(float64(a.Positive), float64(a.Negative))
+}
+
+// CorpusAffect is the Positive Affect and Negative Affect of a corpus of texts.
+type CorpusAffect struct {
+	Texts int
+	// Positive and Negative are the mean PA and NA of the texts.
+	Positive float64
+	Negative float64
+	// PositiveFraction and NegativeFraction are the fractions of the texts that express positive and negative affect.
+	PositiveFraction float64
+	NegativeFraction float64
+}
+
+// Balance returns the affect-balance index of the corpus, (PA - NA) / (PA + NA) computed on the mean PA and NA,
+// from -1 to 1. It is 0 if the corpus expresses neither.
+func (c CorpusAffect) Balance() float64 {
+	return balance(c.Positive, c.Negative)
+}
+
+func balance(positive, negative float64) float64 {
+	if positive+negative == 0 {
+		return 0
+	}
+	return (positive - negative) / (positive + negative)
+}
+
+// generalStates are the items of `GeneralPosNegStates`, sorted.
+var generalStates = func() []string {
+	res := []string{}
+	for s := range GeneralPosNegStates {
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}()
+
+// affectLexicon is the lexicon of the items of `GeneralPosNegStates`, with the default context rules.
+var affectLexicon = &Lexicon{
+	Name: "general", States: generalStates, Categories: GeneralPosNegStates,
+	matcher: newMatcher(generalStates, nil), index: BuildSoundexIndex(generalStates), contexts: builtinContexts(generalStates),
+}
+
+// TextAffect returns the Positive Affect and Negative Affect of a text, outside of quoted, retweeted and reported speech.
+func TextAffect(textString string) Affect {
+	return Analyzer{}.Affect(textString)
+}
+
+// AggregateAffect returns the Positive Affect and Negative Affect of a corpus of texts,
+// outside of quoted, retweeted and reported speech.
+// The texts are expected to be already validated, see `ValidText`.
+func AggregateAffect(texts []string) (CorpusAffect, error) {
+	return Analyzer{}.AggregateAffect(texts)
+}
+
+// Affect returns the Positive Affect and Negative Affect of a text, counting the items counted by the analyzer.
+func (an Analyzer) Affect(textString string) Affect {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.Affect(textString)
+}
+
+// AggregateAffect returns the Positive Affect and Negative Affect of a corpus of texts, counting the items
+// counted by the analyzer. The texts are expected to be already validated, see `Analyzer.ValidText`.
+func (an Analyzer) AggregateAffect(texts []string) (CorpusAffect, error) {
+	if len(texts) == 0 {
+		return CorpusAffect{}, fmt.Errorf("texts is empty")
+	}
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := CorpusAffect{Texts: len(texts)}
+	for _, t := range texts {
+		a := sc.Affect(t)
+		res.Positive += float64(a.Positive)
+		res.Negative += float64(a.Negative)
+		if a.Positive > 0 {
+			res.PositiveFraction++
+		}
+		if a.Negative > 0 {
+			res.NegativeFraction++
+		}
+	}
+	n := float64(len(texts))
+	res.Positive, res.Negative = res.Positive/n, res.Negative/n
+	res.PositiveFraction, res.NegativeFraction = res.PositiveFraction/n, res.NegativeFraction/n
+	return res, nil
+}
+
+// Affect returns the Positive Affect and Negative Affect of a text, counting the items counted by the scanner's analyzer.
+func (sc *Scanner) Affect(textString string) Affect {
+	res := Affect{PositiveItems: []string{}, NegativeItems: []string{}}
+	for _, m := range sc.analyze(textString, affectLexicon.matcher, affectLexicon).States {
+		if !sc.Analyzer.countsState(m) {
+			continue
+		}
+		switch m.Direction {
+		case Positive:
+			if !containsString(res.PositiveItems, m.State) {
+				res.PositiveItems = append(res.PositiveItems, m.State)
+			}
+		case Negative:
+			if !containsString(res.NegativeItems, m.State) {
+				res.NegativeItems = append(res.NegativeItems, m.State)
+			}
+		}
+	}
+	res.Positive, res.Negative = len(res.PositiveItems), len(res.NegativeItems)
+	return res
+}
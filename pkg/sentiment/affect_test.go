@@ -0,0 +1,58 @@
+package sentiment
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTextAffect(t *testing.T) {
+	type testCase struct {
+		textString string
+		positive   []string
+		negative   []string
+		balance    float64
+	}
+	cases := []testCase{
+		{textString: "", positive: []string{}, negative: []string{}, balance: 0},
+		{textString: "I am inspired and interested", positive: []string{"inspired", "interested"}, negative: []string{}, balance: 1},
+		{textString: "I am upset, distressed and upset again", positive: []string{}, negative: []string{"upset", "distressed"}, balance: -1},
+		{textString: "I am active but nervous and upset", positive: []string{"active"}, negative: []string{"nervous", "upset"}, balance: -1.0 / 3},
+		{textString: `he wrote "I am upset"`, positive: []string{}, negative: []string{}, balance: 0},
+		{textString: "I need a strong coffee", positive: []string{}, negative: []string{}, balance: 0}}
+
+	for _, c := range cases {
+		out := TextAffect(c.textString)
+		if out.Positive != len(c.positive) || out.Negative != len(c.negative) || math.Abs(out.Balance()-c.balance) > 1e-9 {
+			t.Errorf("Failed: expected %v and %v, recieved %v for %q", c.positive, c.negative, out, c.textString)
+			continue
+		}
+		for i := range c.positive {
+			if out.PositiveItems[i] != c.positive[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.positive[i], out.PositiveItems[i])
+			}
+		}
+		for i := range c.negative {
+			if out.NegativeItems[i] != c.negative[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.negative[i], out.NegativeItems[i])
+			}
+		}
+	}
+}
+
+func TestAggregateAffect(t *testing.T) {
+	if _, err := AggregateAffect([]string{}); err == nil {
+		t.Errorf("Failed: expected an error for no texts")
+	}
+	out, err := AggregateAffect([]string{"I am inspired and interested", "I am upset", "I am calm", "I am active and afraid"})
+	expected := CorpusAffect{Texts: 4, Positive: 0.75, Negative: 0.5, PositiveFraction: 0.5, NegativeFraction: 0.5}
+	if err != nil || out != expected {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	if math.Abs(out.Balance()-0.2) > 1e-9 {
+		t.Errorf("Failed: expected 0.2, recieved %v", out.Balance())
+	}
+	out, _ = Analyzer{IncludeReported: true}.AggregateAffect([]string{`"I am upset"`})
+	if out.Negative != 1 {
+		t.Errorf("Failed: expected 1, recieved %v", out.Negative)
+	}
+}
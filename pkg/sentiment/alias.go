@@ -0,0 +1,81 @@
+package sentiment
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Topic alias dictionaries. The same subject is referred to in many ways ("covid", "coronavirus", "sars-cov-2",
+"the pandemic", "#COVID19"); a `Topic` can list them as aliases of its canonical name, so that the topic sets
+and their reports only use the canonical name. The dictionaries can be written in code or read from JSON files,
+mapping every canonical name to its aliases.
+*/
+
+// compileAliases returns the query node matching any of the aliases, nil if there is none.
+func compileAliases(aliases []string) (queryNode, error) {
+	if len(aliases) == 0 {
+		return nil, nil
+	}
+	res := orNode{}
+	for _, alias := range aliases {
+		t := &termNode{}
+		for _, w := range text.GenerateValidWords(alias) {
+			if w != "" {
+				t.words = append(t.words, w)
+			}
+		}
+		if len(t.words) == 0 {
+			return nil, fmt.Errorf("alias %q has no letters or digits", alias)
+		}
+		res = append(res, t)
+	}
+	return res, nil
+}
+
+// ReadTopics reads a topic alias dictionary, a JSON object mapping the canonical name of every topic to its aliases,
+// such as {"covid": ["coronavirus", "sars-cov-2", "the pandemic", "#COVID19"]}. The topics are sorted by name,
+// and have no query, so that their query defaults to their name like the topics written in code: a plain word
+// is matched like the topics of `Matcher`, and a name such as "covid OR flu" is a topic query.
+func ReadTopics(r io.Reader) ([]Topic, error) {
+	dict := map[string][]string{}
+	if err := json.NewDecoder(r).Decode(&dict); err != nil {
+		return nil, fmt.Errorf("invalid topics: %v", err)
+	}
+	res := []Topic{}
+	for name, aliases := range dict {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("invalid topics: empty topic name")
+		}
+		if _, err := ParseTopicQuery(name); err != nil {
+			return nil, fmt.Errorf("invalid topics: topic %q: %v", name, err)
+		}
+		if _, err := compileAliases(aliases); err != nil {
+			return nil, fmt.Errorf("invalid topics: topic %q: %v", name, err)
+		}
+		res = append(res, Topic{Name: name, Aliases: aliases})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res, nil
+}
+
+// WriteTopics writes the aliases of the topics as the JSON object read by `ReadTopics`.
+// The queries of the topics are not written.
+func WriteTopics(w io.Writer, topics []Topic) error {
+	dict := map[string][]string{}
+	for _, t := range topics {
+		name := t.Name
+		if name == "" {
+			name = t.Query
+		}
+		dict[name] = append([]string{}, t.Aliases...)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(dict)
+}
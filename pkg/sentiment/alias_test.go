@@ -0,0 +1,109 @@
+package sentiment
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var covidTopic = Topic{Name: "covid", Aliases: []string{"coronavirus", "sars-cov-2", "the pandemic", "#COVID19"}}
+
+func TestTopicAliases(t *testing.T) {
+	set, err := NewTopicSet(covidTopic, Topic{Name: "vaccine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type testCase struct {
+		textString string
+		expected   []string
+	}
+	testCases := []testCase{
+		{textString: "I am sad about covid", expected: []string{"covid"}},
+		{textString: "I am sad about the Coronavirus", expected: []string{"covid"}},
+		{textString: "SARS-CoV-2 is here", expected: []string{"covid"}},
+		{textString: "The pandemic vaccine", expected: []string{"covid", "vaccine"}},
+		{textString: "a pandemic", expected: []string{}},
+		{textString: "#covid19 news", expected: []string{"covid"}}}
+	for _, tc := range testCases {
+		if out := set.Match(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+	rule, err := set.Rule("covid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !Check("I am sad about the pandemic", And(rule, SelfRefAndState)) || Check("I am sad", rule) {
+		t.Errorf("Failed: expected the rule to match the aliases of the topic")
+	}
+	if _, err := set.Rule("flu"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown topic")
+	}
+	reports, err := AggregateTopics([]string{"I am sad about the pandemic", "I am happy about covid"}, set)
+	if err != nil || reports[0].Topic != "covid" || reports[0].Valid != 2 {
+		t.Errorf("Failed: expected %v valid texts for %v, recieved %v, %v", 2, "covid", reports, err)
+	}
+	if _, err := NewTopicSet(Topic{Name: "broken", Aliases: []string{"!!!"}}); err == nil {
+		t.Errorf("Failed: expected an error for an alias with no letters or digits")
+	}
+}
+
+func TestReadTopics(t *testing.T) {
+	topics, err := ReadTopics(strings.NewReader(`{"vaccine": ["jab"], "covid": ["coronavirus", "the pandemic"]}`))
+	expected := []Topic{
+		{Name: "covid", Aliases: []string{"coronavirus", "the pandemic"}},
+		{Name: "vaccine", Aliases: []string{"jab"}}}
+	if err != nil || !reflect.DeepEqual(topics, expected) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, topics, err)
+	}
+	var buf bytes.Buffer
+	if err := WriteTopics(&buf, topics); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := ReadTopics(&buf); err != nil || !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	for _, s := range []string{`["covid"]`, `{"covid": ["!!!"]}`, `{"": ["covid"]}`, `{"!!!": []}`, `{"\"covid": []}`} {
+		if _, err := ReadTopics(strings.NewReader(s)); err == nil {
+			t.Errorf("Failed: expected an error for %v", s)
+		}
+	}
+}
+
+func TestReadTopicsNames(t *testing.T) {
+	names := []string{"climate change", "covid OR flu", "vaccine"}
+	topics, err := ReadTopics(strings.NewReader(`{"climate change": [], "covid OR flu": [], "vaccine": []}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := NewTopicSet(topics...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	written, err := NewTopicSet(Topic{Name: names[0]}, Topic{Name: names[1]}, Topic{Name: names[2]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	type testCase struct {
+		textString string
+		expected   []string
+	}
+	testCases := []testCase{
+		{textString: "I am sad about climate change", expected: []string{"climate change"}},
+		{textString: "the climate will change", expected: []string{"climate change"}},
+		{textString: "I have covd", expected: []string{"covid OR flu"}},
+		{textString: "my vacine is today", expected: []string{"vaccine"}},
+		{textString: "I have a cold", expected: []string{}}}
+	for _, tc := range testCases {
+		if out := read.Match(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+		if out := written.Match(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+	if _, err := ReadTopics(strings.NewReader(`{"(ai": []}`)); err == nil {
+		t.Errorf("Failed: expected an error for a name that is an invalid query")
+	}
+}
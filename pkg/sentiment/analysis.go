@@ -0,0 +1,243 @@
+package sentiment
+
+/*
+Detailed analysis of a single text, and the `Analyzer` settings deciding which of its matches are counted.
+*/
+
+// Analysis is the detailed analysis of a single text. It contains every match found in the text,
+// flagged with the context it appears in; see `Analyzer` for the matches that are counted.
+type Analysis struct {
+	Text string
+	// Words are the processed words of the text, as returned by `text.GenerateValidWords`.
+	Words []string
+	// Speech is the kind of speech of every word, indexed like Words.
+	Speech []Speech
+	// Modality is the modality of every word, indexed like Words.
+	Modality []Modality
+	// Tense is the temporal reference of every word, indexed like Words.
+	Tense []Tense
+	// Attribution is the person every word is attributed to, indexed like Words.
+	Attribution []Attribution
+	// Negated is true for every word that follows a negation in its clause, indexed like Words.
+	Negated []bool
+	// Hits are the self references, states, modifiers and topics found in the text, in the order they appear.
+	Hits     []Hit
+	SelfRefs []SelfRefMatch
+	States   []StateMatch
+	// Links are the states directed at a topic, found by `Analyzer.AnalyzeTopic`, and empty otherwise.
+	Links []TopicLink
+}
+
+// Analyze returns the detailed analysis of a text.
+func Analyze(textString string) Analysis {
+	sc := getScanner(Analyzer{})
+	defer putScanner(sc)
+	return sc.Analyze(textString).clone()
+}
+
+// clone returns a copy of the analysis that does not share its slices, such as the one of an analysis of a `Scanner`.
+func (a Analysis) clone() Analysis {
+	a.Words = append([]string(nil), a.Words...)
+	a.Speech = append([]Speech(nil), a.Speech...)
+	a.Modality = append([]Modality(nil), a.Modality...)
+	a.Tense = append([]Tense(nil), a.Tense...)
+	a.Attribution = append([]Attribution(nil), a.Attribution...)
+	a.Negated = append([]bool(nil), a.Negated...)
+	a.Hits = append([]Hit(nil), a.Hits...)
+	a.SelfRefs = append([]SelfRefMatch(nil), a.SelfRefs...)
+	a.States = append([]StateMatch(nil), a.States...)
+	a.Links = append([]TopicLink(nil), a.Links...)
+	return a
+}
+
+// Analyzer holds the settings deciding how texts are analyzed and counted.
+// The zero value counts the author's own statements only, and resolves multi-category texts with `CountAll`.
+type Analyzer struct {
+	// Strategy decides how a text with states of several categories is counted.
+	Strategy ConflictStrategy
+	// IncludeReported counts the self references and states found in quoted, retweeted and reported speech.
+	IncludeReported bool
+	// ExcludeNonAssertive ignores the states found in questions, conditionals, modal clauses and wishes.
+	ExcludeNonAssertive bool
+	// ExcludeNegated ignores the negated states, such as "I am not happy", see `StateMatch.Negated`.
+	ExcludeNegated bool
+	// PresentOnly ignores the states that refer to the past or to the future.
+	PresentOnly bool
+	// Attributions restricts the counted states to the ones attributed to these persons.
+	// All the states are counted when it is empty.
+	Attributions []Attribution
+	// Exclusions are the processed words that are not counted as a state, by state, such as the
+	// phonetic false positives found by `Audit`. See `Exclusions`, `ReadExclusions` and `Lexicon.ReadExclusions`.
+	Exclusions map[string][]string
+	// MinConfidence ignores the self references and states with a lower confidence, see `StateMatch.Confidence`.
+	MinConfidence float64
+	// Lexicon is the lexicon of the detected states. It defaults to `PANASt`.
+	Lexicon *Lexicon
+	// TopicDistance also links the states to a topic when they are in the same clause, with at most TopicDistance
+	// words between them, see `Analyzer.AnalyzeTopic`. Only the link patterns are used when it is 0.
+	TopicDistance int
+	// Weighted scales the weight with which a text counts in each category by the weight of its most intense
+	// state in the lexicon, see `StateC.Weight`, in the category weights and in their aggregates.
+	Weighted bool
+	// SumWeights scales the weight with which a text counts in each category by the sum of the weights of its
+	// distinct states instead, so that "furious and irritable" outweighs "furious". Every state weighs 1 unless
+	// the analyzer is weighted. The weight of a text in a category, and their aggregates, can then exceed 1.
+	SumWeights bool
+}
+
+// lexicon returns the lexicon of the analyzer.
+func (an Analyzer) lexicon() *Lexicon {
+	if an.Lexicon == nil {
+		return PANASt
+	}
+	return an.Lexicon
+}
+
+// weights returns the lexicon entries that weigh the categories, nil if the analyzer is not weighted.
+func (an Analyzer) weights() map[string]StateC {
+	if !an.Weighted {
+		return nil
+	}
+	return an.lexicon().Categories
+}
+
+// CountedSelfRefs returns the self-reference matches of the analysis that are counted by the analyzer.
+func (an Analyzer) CountedSelfRefs(a Analysis) []SelfRefMatch {
+	res := []SelfRefMatch{}
+	for _, m := range a.SelfRefs {
+		if an.countsSelfRef(m) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// CountedStates returns the state matches of the analysis that are counted by the analyzer.
+func (an Analyzer) CountedStates(a Analysis) []StateMatch {
+	return an.appendCountedStates([]StateMatch{}, a)
+}
+
+func (an Analyzer) appendCountedStates(dst []StateMatch, a Analysis) []StateMatch {
+	for _, m := range a.States {
+		if an.countsState(m) {
+			dst = append(dst, m)
+		}
+	}
+	return dst
+}
+
+func (an Analyzer) countsSelfRef(m SelfRefMatch) bool {
+	return an.selfRefRejection(m) == 0
+}
+
+// selfRefRejection returns the reason why the self reference is not counted, 0 if it is counted.
+func (an Analyzer) selfRefRejection(m SelfRefMatch) Reason {
+	switch {
+	case !an.IncludeReported && m.Speech != Direct:
+		return ExcludedSpeech
+	case an.MinConfidence > 0 && m.Confidence < an.MinConfidence:
+		return LowConfidence
+	}
+	return 0
+}
+
+func (an Analyzer) countsState(m StateMatch) bool {
+	return an.stateRejection(m) == 0
+}
+
+// stateRejection returns the reason why the state is not counted, 0 if it is counted.
+func (an Analyzer) stateRejection(m StateMatch) Reason {
+	switch {
+	case !an.IncludeReported && m.Speech != Direct:
+		return ExcludedSpeech
+	case an.ExcludeNonAssertive && m.Modality != Assertive:
+		return ExcludedModality
+	case an.ExcludeNegated && m.Negated:
+		return ExcludedNegation
+	case an.PresentOnly && m.Tense != Present:
+		return ExcludedTense
+	case len(an.Attributions) > 0 && !containsAttribution(an.Attributions, m.Attribution):
+		return ExcludedAttribution
+	case excludedWord(an.Exclusions[m.State], m.Word):
+		return ExcludedWord
+	case an.MinConfidence > 0 && m.Confidence < an.MinConfidence:
+		return LowConfidence
+	}
+	return 0
+}
+
+// valid checks if the analysis contains a counted self reference and a counted state, see `SelfRefAndState`.
+func (an Analyzer) valid(a Analysis) bool {
+	return SelfRefAndState(an, a)
+}
+
+// ValidText returns true if it finds the text to be valid to be considered for sentiment analysis.
+// Means, the text must contain a counted self reference and a counted sentiment state.
+func (an Analyzer) ValidText(textString string) bool {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.ValidText(textString)
+}
+
+// ValidTextWithTopic returns true if it finds the text to be valid to be considered for sentiment analysis on a topic.
+// Means, the text must contain the target topic, a counted self reference, and a counted sentiment state.
+// The topic can be a topic query, such as "corona OR covid", see `ParseTopicQuery`; an invalid query is never
+// matched, see `CheckTopic`.
+func (an Analyzer) ValidTextWithTopic(textString, topic string) bool {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.ValidTextWithTopic(textString, topic)
+}
+
+// States detrmines the counted sentiment states of a text, in the order they first appear.
+func (an Analyzer) States(textString string) []string {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	seen := map[string]bool{}
+	res := []string{}
+	for _, m := range an.CountedStates(*sc.Analyze(textString)) {
+		if !seen[m.State] {
+			seen[m.State] = true
+			res = append(res, m.State)
+		}
+	}
+	return res
+}
+
+// CategoryWeights determines the sentiment categories of a text, resolved with the analyzer's strategy,
+// along with the weight with which the text counts in each of them, scaled by the weights of the states
+// if the analyzer is weighted.
+func (an Analyzer) CategoryWeights(textString string) map[Category]float64 {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := map[Category]float64{}
+	sc.AddCategoryWeights(res, textString)
+	return res
+}
+
+// Categories detrmines the sentiment categories of a text, resolved with the analyzer's strategy.
+func (an Analyzer) Categories(textString string) []Category {
+	res := []Category{}
+	for c := range an.CategoryWeights(textString) {
+		res = append(res, c)
+	}
+	return res
+}
+
+func containsAttribution(coll []Attribution, a Attribution) bool {
+	for _, c := range coll {
+		if c == a {
+			return true
+		}
+	}
+	return false
+}
+
+func excludedWord(exclusions []string, word string) bool {
+	for _, e := range exclusions {
+		if e == word {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestAnalyze(t *testing.T) {
+	out := Analyze(`I am happy, he wrote "I am sad"`)
+	expectedSelfRefs := []SelfRefMatch{
+		{SelfRef: "I am", Word: "i", Position: 0, Speech: Direct, Kind: ExactMatch, Confidence: 1},
+		{SelfRef: "I", Word: "i", Position: 0, Speech: Direct, Kind: ExactMatch, Confidence: 1},
+		{SelfRef: "am", Word: "am", Position: 1, Speech: Direct, Kind: ExactMatch, Confidence: 1},
+		{SelfRef: "I am", Word: "i", Position: 5, Speech: Quoted, Kind: ExactMatch, Confidence: 1},
+		{SelfRef: "I", Word: "i", Position: 5, Speech: Quoted, Kind: ExactMatch, Confidence: 1},
+		{SelfRef: "am", Word: "am", Position: 6, Speech: Quoted, Kind: ExactMatch, Confidence: 1}}
+	expectedStates := []StateMatch{
+		{State: "happy", Word: "happy", Position: 2, Length: 1, Category: "joviality", Direction: "positive", Speech: Direct, Kind: ExactMatch, Confidence: 1},
+		{State: "sad", Word: "sad", Position: 7, Length: 1, Category: "sadness", Direction: "negative", Speech: Quoted, Kind: ExactMatch, Confidence: 1}}
+	if len(out.SelfRefs) != len(expectedSelfRefs) || len(out.States) != len(expectedStates) {
+		t.Fatalf("Failed: expected %v and %v, recieved %v and %v", expectedSelfRefs, expectedStates, out.SelfRefs, out.States)
+	}
+	for i := range out.SelfRefs {
+		if out.SelfRefs[i] != expectedSelfRefs[i] {
+			t.Errorf("Failed: expected %v, recieved %v", expectedSelfRefs[i], out.SelfRefs[i])
+		}
+	}
+	for i := range out.States {
+		if out.States[i] != expectedStates[i] {
+			t.Errorf("Failed: expected %v, recieved %v", expectedStates[i], out.States[i])
+		}
+	}
+}
+
+func TestAnalyzerValidText(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   bool
+	}
+	cases := []testCase{
+		{analyzer: Analyzer{}, textString: "I am happy", expected: true},
+		{analyzer: Analyzer{}, textString: `"I am happy" is a nice song`, expected: false},
+		{analyzer: Analyzer{}, textString: "RT @anna: I am happy", expected: false},
+		{analyzer: Analyzer{}, textString: "she told me she is happy", expected: false},
+		{analyzer: Analyzer{IncludeReported: true}, textString: "RT @anna: I am happy", expected: true},
+		{analyzer: Analyzer{IncludeReported: true}, textString: "she told me she is happy", expected: true}}
+
+	for _, c := range cases {
+		out := c.analyzer.ValidText(c.textString)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+		}
+	}
+}
+
+func TestAnalyzerStates(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   []string
+	}
+	cases := []testCase{
+		{analyzer: Analyzer{}, textString: `I am happy, he wrote "I am sad"`, expected: []string{"happy"}},
+		{analyzer: Analyzer{IncludeReported: true}, textString: `I am happy, he wrote "I am sad"`, expected: []string{"happy", "sad"}}}
+
+	for _, c := range cases {
+		out := c.analyzer.States(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+}
+
+func FuzzAnalyze(f *testing.F) {
+	for _, s := range []string{
+		"", "-", "I am - happy", `she said "I am sad`, "RT @", "am I happy?", "\xc3", "I'll be nervous tomorrow",
+		"my mom is scared", "> I am sad\n\n>", "he said he said he said",
+	} {
+		f.Add(s, "covid")
+	}
+	f.Fuzz(func(t *testing.T, s string, topic string) {
+		a := Analyze(s)
+		if len(a.Speech) != len(a.Words) || len(a.Modality) != len(a.Words) || len(a.Tense) != len(a.Words) ||
+			len(a.Attribution) != len(a.Words) {
+			t.Fatalf("Failed: analysis of %q is not aligned with its words", s)
+		}
+		for _, m := range a.States {
+			if m.Position < 0 || m.Position >= len(a.Words) {
+				t.Errorf("Failed: match %v is out of the words of %q", m, s)
+			}
+		}
+		an := Analyzer{ExcludeNonAssertive: true, PresentOnly: true}
+		an.ValidText(s)
+		an.ValidTextWithTopic(s, topic)
+		States(s)
+		for _, strategy := range []ConflictStrategy{CountAll, FirstMatch, Dominant, DropConflicting, Fractional} {
+			for c, w := range CategoryWeights(s, strategy) {
+				if w <= 0 || w > 1 {
+					t.Errorf("Failed: weight %v of %v is out of range", w, c)
+				}
+			}
+			if _, err := AggregateCategories([]string{s, topic}, strategy); err != nil {
+				t.Errorf("Failed: unexpected error %v", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,267 @@
+package sentiment
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Single-pass annotation of the words of a text with their speech, modality, tense, attribution and negation.
+Every token is looked up once, in `wordInfos`, and its punctuation is read once, past its closing quotes,
+so that the rules of speech.go, modality.go, tense.go, attribution.go and negation.go share a single pass
+over the sentences and the clauses of the text.
+*/
+
+// wordRole is the set of roles a processed word plays in the annotation rules.
+type wordRole uint32
+
+const (
+	conditionalRole wordRole = 1 << iota
+	wishRole
+	modalRole
+	questionOpenerRole
+	pronounRole
+	reportingVerbRole
+	reportingSubjectRole
+	determinerRole
+	retweetPrefixRole
+	negationRole
+	possessiveRole
+	personNounRole
+	conjunctionRole
+	auxiliaryRole
+	adverbialRole
+	referenceRole
+)
+
+// wordInfo is the roles of a processed word, along with the tense it sets as an auxiliary verb
+// or as a time adverbial, and the person it refers to as a reference.
+type wordInfo struct {
+	roles       wordRole
+	auxiliary   Tense
+	adverbial   Tense
+	attribution Attribution
+}
+
+// wordInfos are the roles of the marker words of the annotation rules.
+var wordInfos = buildWordInfos()
+
+func buildWordInfos() map[string]wordInfo {
+	res := map[string]wordInfo{}
+	add := func(words map[string]bool, role wordRole) {
+		for w := range words {
+			info := res[w]
+			info.roles |= role
+			res[w] = info
+		}
+	}
+	add(conditionalMarkers, conditionalRole)
+	add(wishMarkers, wishRole)
+	add(modalMarkers, modalRole)
+	add(questionOpeners, questionOpenerRole)
+	add(pronouns, pronounRole)
+	add(reportingVerbs, reportingVerbRole)
+	add(reportingSubjects, reportingSubjectRole)
+	add(determiners, determinerRole)
+	add(retweetPrefixes, retweetPrefixRole)
+	add(negations, negationRole)
+	add(possessives, possessiveRole)
+	add(personNouns, personNounRole)
+	add(clauseConjunctions, conjunctionRole)
+	for w, t := range tenseAuxiliaries {
+		info := res[w]
+		info.roles, info.auxiliary = info.roles|auxiliaryRole, t
+		res[w] = info
+	}
+	for w, t := range timeAdverbials {
+		info := res[w]
+		info.roles, info.adverbial = info.roles|adverbialRole, t
+		res[w] = info
+	}
+	for w, a := range attributionReferences {
+		info := res[w]
+		info.roles, info.attribution = info.roles|referenceRole, a
+		res[w] = info
+	}
+	return res
+}
+
+// tokenMark is the set of punctuation marks that end a raw token, past its closing quotation marks and brackets.
+type tokenMark uint8
+
+const (
+	// sentenceMark ends a sentence: a full stop, an exclamation or a question mark, or a line break.
+	sentenceMark tokenMark = 1 << iota
+	// clauseMark ends a clause: a full stop, an exclamation or a question mark, a semicolon or a colon.
+	clauseMark
+	// questionMark ends a question.
+	questionMark
+	// commaMark is a comma.
+	commaMark
+	// willMark is the contraction of "will", such as "I'll".
+	willMark
+)
+
+// tokenInfo is the roles of the processed word of a token, along with the marks of its raw token.
+type tokenInfo struct {
+	wordInfo
+	marks tokenMark
+}
+
+// endsClause checks if the token ends a clause, including with a comma.
+func (info tokenInfo) endsClause() bool {
+	return info.marks&(clauseMark|commaMark) != 0
+}
+
+// appendTokenInfos appends the infos of every token to dst[:0].
+func appendTokenInfos(dst []tokenInfo, tokens []text.Token) []tokenInfo {
+	res := dst[:0]
+	var buf [16]byte
+	for _, tk := range tokens {
+		info := tokenInfo{wordInfo: wordInfos[tk.Word]}
+		raw := tk.Raw
+		if strings.IndexByte(raw, '\n') >= 0 {
+			info.marks |= sentenceMark
+		}
+		if trimmed := trimQuotes(raw); trimmed != "" {
+			switch trimmed[len(trimmed)-1] {
+			case '.', '!':
+				info.marks |= sentenceMark | clauseMark
+			case '?':
+				info.marks |= sentenceMark | clauseMark | questionMark
+			case ';', ':':
+				info.marks |= clauseMark
+			case ',':
+				info.marks |= commaMark
+			}
+		}
+		if strings.ContainsAny(raw, "'’") {
+			// the reference of a contraction is its part before the apostrophe, such as "we" for "We're"
+			if hasWillContraction(raw) {
+				info.marks |= willMark
+			}
+			info.roles &^= referenceRole
+			if a, ok := attributionReferences[string(appendContractionBase(buf[:0], raw))]; ok {
+				info.roles, info.attribution = info.roles|referenceRole, a
+			}
+		}
+		res = append(res, info)
+	}
+	return res
+}
+
+// trimQuotes removes the closing quotation marks and brackets from a raw token.
+func trimQuotes(raw string) string {
+	for raw != "" {
+		r, size := utf8.DecodeLastRuneInString(raw)
+		switch r {
+		case '"', '”', '»', '\'', ')', '\n':
+			raw = raw[:len(raw)-size]
+		default:
+			return raw
+		}
+	}
+	return raw
+}
+
+// annotate appends the speech, modality, tense, attribution and negation of every token to the ones of the analysis,
+// reset beforehand, in a single pass over the sentences and the clauses of the tokens.
+func annotate(a *Analysis, tokens []text.Token, infos []tokenInfo) {
+	a.Speech, a.Modality, a.Tense, a.Attribution, a.Negated = a.Speech[:0], a.Modality[:0], a.Tense[:0], a.Attribution[:0], a.Negated[:0]
+	var quotes quoteState
+	// reported is the position of the reporting verb of the current reported clause, -1 if there is none
+	reported, retweeted := -1, false
+	for start := 0; start < len(tokens); {
+		end := start
+		for end < len(tokens)-1 && infos[end].marks&sentenceMark == 0 {
+			end++
+		}
+		question := infos[end].marks&questionMark != 0 ||
+			(end > start && infos[start].roles&questionOpenerRole != 0 && infos[start+1].roles&pronounRole != 0)
+		attribution := FirstSingular
+		for clauseStart := start; clauseStart <= end; {
+			clauseEnd := clauseStart
+			for clauseEnd < end && !infos[clauseEnd].endsClause() {
+				clauseEnd++
+			}
+			adverbial, hasAdverbial := Present, false
+			for i := clauseStart; i <= clauseEnd && !hasAdverbial; i++ {
+				adverbial, hasAdverbial = infos[i].adverbial, infos[i].roles&adverbialRole != 0
+			}
+			modality, tense, hasAuxiliary := Assertive, adverbial, false
+			// negation is the position of the last negation of the clause, -1 if there is none
+			negation := -1
+			for i := clauseStart; i <= clauseEnd; i++ {
+				info := infos[i]
+
+				speech := quotes.next(tokens[i].Raw)
+				if info.roles&reportingVerbRole != 0 && reportedSubject(tokens, infos, i) {
+					reported = i
+				}
+				if reported >= 0 && speech == Direct {
+					speech = Reported
+				}
+				if info.roles&retweetPrefixRole != 0 && i+1 < len(tokens) && strings.HasPrefix(tokens[i+1].Raw, "@") {
+					retweeted = true
+				}
+				if retweeted {
+					speech = Retweeted
+				}
+				a.Speech = append(a.Speech, speech)
+				if reported >= 0 && i > reported && info.marks&clauseMark != 0 {
+					reported = -1
+				}
+
+				switch {
+				case info.roles&conditionalRole != 0:
+					modality = Conditional
+				case info.roles&wishRole != 0 && modality != Conditional:
+					modality = Wish
+				case info.roles&modalRole != 0 && modality == Assertive:
+					modality = Modal
+				}
+				if question {
+					a.Modality = append(a.Modality, Interrogative)
+				} else {
+					a.Modality = append(a.Modality, modality)
+				}
+
+				if t, ok := auxiliaryTense(tokens, infos, i); ok {
+					tense, hasAuxiliary = t, true
+				}
+				if hasAuxiliary || hasAdverbial {
+					a.Tense = append(a.Tense, tense)
+				} else {
+					a.Tense = append(a.Tense, Present)
+				}
+
+				if info.roles&referenceRole != 0 {
+					attribution = info.attribution
+				} else if i > 0 && infos[i-1].roles&possessiveRole != 0 && info.roles&personNounRole != 0 {
+					attribution = Third
+				}
+				a.Attribution = append(a.Attribution, attribution)
+
+				a.Negated = append(a.Negated, negation >= 0 && i-negation-1 <= maxNegationGap)
+				switch {
+				case info.roles&negationRole != 0:
+					negation = i
+				case info.roles&conjunctionRole != 0:
+					negation = -1
+				}
+			}
+			clauseStart = clauseEnd + 1
+		}
+		start = end + 1
+	}
+}
+
+// annotateText returns the annotations of the words of a text, see `annotate`.
+func annotateText(textString string) Analysis {
+	tokens := text.Tokenize(textString)
+	var a Analysis
+	annotate(&a, tokens, appendTokenInfos(nil, tokens))
+	return a
+}
@@ -0,0 +1,230 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Aspect-level sentiment. `ValidTextWithTopic` only checks that a topic and a state co-occur in a text:
+"I'm happy the covid rules ended" and "covid makes me scared" are alike. The link rules below decide
+whether a state is directed at the topic, from the words between them in their clause:
+
+	scared of covid, happy about the new rules  a state followed by a preposition and the topic (`PrepositionLink`)
+	covid makes me scared, the news got us sad   the topic causing a state of the author (`CausativeLink`)
+
+With `Analyzer.TopicDistance`, a state and a topic close enough in the same clause are linked too (`ProximityLink`).
+*/
+
+// LinkKind is the rule that links a state to a topic.
+type LinkKind int
+
+const (
+	// PrepositionLink is a state followed by a preposition and the topic: "STATE about/of/at TOPIC".
+	PrepositionLink LinkKind = iota
+	// CausativeLink is the topic causing the state of the author: "TOPIC makes me STATE".
+	CausativeLink
+	// ProximityLink is a state and the topic in the same clause, at most `Analyzer.TopicDistance` words apart.
+	ProximityLink
+)
+
+var linkKindNames = map[LinkKind]string{
+	PrepositionLink: "preposition",
+	CausativeLink:   "causative",
+	ProximityLink:   "proximity",
+}
+
+// String returns the name of the link kind.
+func (k LinkKind) String() string {
+	if name, ok := linkKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("LinkKind(%d)", int(k))
+}
+
+// TopicLink is a state directed at a topic.
+type TopicLink struct {
+	Kind LinkKind
+	// State is the index of the state match in `Analysis.States`.
+	State int
+	// Position and Length locate the words of the topic.
+	Position int
+	Length   int
+}
+
+// linkPrepositions introduce the target of a state.
+var linkPrepositions = map[string]bool{
+	"about": true, "of": true, "at": true, "with": true, "over": true, "for": true, "by": true,
+	"from": true, "regarding": true, "toward": true, "towards": true, "because": true,
+}
+
+// causativeVerbs put their object in a state.
+var causativeVerbs = map[string]bool{
+	"make": true, "makes": true, "made": true, "making": true,
+	"get": true, "gets": true, "got": true, "getting": true,
+	"has": true, "had": true, "keeps": true, "kept": true, "leaves": true, "left": true,
+}
+
+// causativeObjects are the objects of the causative verbs that refer to the author.
+var causativeObjects = map[string]bool{"me": true, "us": true, "myself": true}
+
+// clauseConjunctions separate the clauses of a sentence.
+var clauseConjunctions = map[string]bool{
+	"and": true, "but": true, "or": true, "while": true, "although": true, "though": true, "whereas": true,
+}
+
+// maxLinkGap is the number of words allowed between the parts of the link patterns:
+// between a preposition and the topic, between the topic and a causative verb, and between its object and the state.
+const maxLinkGap = 3
+
+// AnalyzeTopic returns the detailed analysis of a text, with the states directed at the topic in `Analysis.Links`.
+// The topic can be a topic query, see `ParseTopicQuery`.
+func AnalyzeTopic(textString, topic string) Analysis {
+	return Analyzer{}.AnalyzeTopic(textString, topic)
+}
+
+// AnalyzeTopic returns the detailed analysis of a text, with the states directed at the topic in `Analysis.Links`,
+// linked by the pattern rules and, if `Analyzer.TopicDistance` is set, by their proximity.
+func (an Analyzer) AnalyzeTopic(textString, topic string) Analysis {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.AnalyzeTopic(textString, topic).clone()
+}
+
+// AnalyzeTopic returns the detailed analysis of a text, with the states directed at the topic in `Analysis.Links`.
+// The analysis belongs to the scanner, and is only valid until the next call. See `Analyzer.AnalyzeTopic`.
+func (sc *Scanner) AnalyzeTopic(textString, topic string) *Analysis {
+	a := sc.analyzeWithTopic(textString, topic)
+	var spans [][2]int
+	if sc.topicQuery != nil {
+		spans = sc.topicQuery.appendSpans(spans, sc.queryContext(a))
+	} else {
+		for _, h := range a.Hits {
+			if h.Kind == TopicHit && h.Pattern == topic {
+				spans = append(spans, [2]int{h.Position, h.Length})
+			}
+		}
+	}
+	for j, span := range spans {
+		if containsSpan(spans[:j], span) {
+			continue
+		}
+		for i, m := range a.States {
+			if shadowed(a.States, m) {
+				continue
+			}
+			if kind, ok := sc.link(m, span[0], span[1]); ok {
+				a.Links = append(a.Links, TopicLink{Kind: kind, State: i, Position: span[0], Length: span[1]})
+			}
+		}
+	}
+	return a
+}
+
+// shadowed checks if the words of the state match belong to a longer state match, such as "angry" and "self"
+// in "angry at self", which is the only one of them linked to the topics.
+func shadowed(states []StateMatch, m StateMatch) bool {
+	for _, n := range states {
+		if n.Length > m.Length && n.Position <= m.Position && m.Position+m.Length <= n.Position+n.Length {
+			return true
+		}
+	}
+	return false
+}
+
+// link returns the rule that links the state to the topic at the supplied position, if any.
+func (sc *Scanner) link(m StateMatch, position, length int) (LinkKind, bool) {
+	words := sc.analysis.Words
+	stateEnd, topicEnd := m.Position+m.Length, position+length
+	switch {
+	case position >= stateEnd && !sc.crossesClause(m.Position, position):
+		// STATE prep [up to maxLinkGap words] TOPIC
+		if position > stateEnd && linkPrepositions[words[stateEnd]] && position-stateEnd-1 <= maxLinkGap {
+			return PrepositionLink, true
+		}
+	case m.Position >= topicEnd && !sc.crossesClause(position, m.Position):
+		// TOPIC [up to maxLinkGap words] VERB me [up to maxLinkGap words] STATE
+		for v := topicEnd; v < m.Position-1 && v-topicEnd <= maxLinkGap; v++ {
+			if causativeVerbs[words[v]] && causativeObjects[words[v+1]] && m.Position-v-2 <= maxLinkGap {
+				return CausativeLink, true
+			}
+		}
+	}
+	if d := sc.Analyzer.TopicDistance; d > 0 {
+		// the number of words between the state and the topic, in the same clause
+		switch {
+		case position >= stateEnd && position-stateEnd <= d && !sc.crossesClause(m.Position, position):
+			return ProximityLink, true
+		case m.Position >= topicEnd && m.Position-topicEnd <= d && !sc.crossesClause(position, m.Position):
+			return ProximityLink, true
+		}
+	}
+	return 0, false
+}
+
+// crossesClause checks if a clause ends between the words at the positions start and end of the last analyzed text.
+func (sc *Scanner) crossesClause(start, end int) bool {
+	for i := start; i < end && i < len(sc.infos); i++ {
+		if sc.infos[i].endsClause() || sc.infos[i].marks&sentenceMark != 0 || (i > start && sc.infos[i].roles&conjunctionRole != 0) {
+			return true
+		}
+	}
+	return false
+}
+
+// DirectedStates returns the counted states of the text that are directed at the topic, in the order they
+// first appear. See `Analyzer.AnalyzeTopic`.
+func (an Analyzer) DirectedStates(textString, topic string) []string {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	a := sc.AnalyzeTopic(textString, topic)
+	seen := map[string]bool{}
+	res := []string{}
+	for i, m := range a.States {
+		if !seen[m.State] && an.countsState(m) && linked(a.Links, i) {
+			seen[m.State] = true
+			res = append(res, m.State)
+		}
+	}
+	return res
+}
+
+// ValidTextTowardTopic returns true if the text is valid to be considered for sentiment analysis on a topic,
+// with a counted state directed at the topic. See `Analyzer.ValidTextTowardTopic`.
+func ValidTextTowardTopic(textString, topic string) bool {
+	return Analyzer{}.ValidTextTowardTopic(textString, topic)
+}
+
+// ValidTextTowardTopic returns true if the text contains a counted self reference and a counted state
+// directed at the topic, see `Analyzer.AnalyzeTopic`.
+func (an Analyzer) ValidTextTowardTopic(textString, topic string) bool {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	a := sc.AnalyzeTopic(textString, topic)
+	if !HasSelfRef()(an, *a) {
+		return false
+	}
+	for _, l := range a.Links {
+		if an.countsState(a.States[l.State]) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsSpan(spans [][2]int, span [2]int) bool {
+	for _, s := range spans {
+		if s == span {
+			return true
+		}
+	}
+	return false
+}
+
+func linked(links []TopicLink, state int) bool {
+	for _, l := range links {
+		if l.State == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,123 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAnalyzeTopic(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		topic      string
+		expected   []LinkKind
+	}
+	testCases := []testCase{
+		{analyzer: Analyzer{}, textString: "I am scared of covid", topic: "covid", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{}, textString: "I am scared about the new covid rules", topic: "covid", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{}, textString: "covid makes me scared", topic: "covid", expected: []LinkKind{CausativeLink}},
+		{analyzer: Analyzer{}, textString: "covid really got us very nervous", topic: "covid", expected: []LinkKind{CausativeLink}},
+		{analyzer: Analyzer{}, textString: "I'm happy the covid rules ended", topic: "covid", expected: []LinkKind{}},
+		{analyzer: Analyzer{}, textString: "I am scared of spiders, covid is fine", topic: "covid", expected: []LinkKind{}},
+		{analyzer: Analyzer{}, textString: "covid is over and it makes me happy", topic: "covid", expected: []LinkKind{}},
+		{analyzer: Analyzer{}, textString: "I am scared of corona", topic: "covid OR corona", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{}, textString: "I am scared of covid", topic: "covid OR covid*", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{}, textString: "I am at ease about covid", topic: "covid", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{}, textString: "I am angry at self over covid", topic: "covid", expected: []LinkKind{PrepositionLink}},
+		{analyzer: Analyzer{TopicDistance: 3}, textString: "I'm happy that the covid rules ended", topic: "covid", expected: []LinkKind{ProximityLink}},
+		{analyzer: Analyzer{TopicDistance: 3}, textString: "I'm happy. covid rules ended", topic: "covid", expected: []LinkKind{}},
+		{analyzer: Analyzer{TopicDistance: 1}, textString: "I'm happy that the covid rules ended", topic: "covid", expected: []LinkKind{}}}
+	for _, tc := range testCases {
+		out := []LinkKind{}
+		for _, l := range tc.analyzer.AnalyzeTopic(tc.textString, tc.topic).Links {
+			out = append(out, l.Kind)
+		}
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestTopicLink(t *testing.T) {
+	a := AnalyzeTopic("I am scared of the new covid rules", "covid")
+	expected := []TopicLink{{Kind: PrepositionLink, State: 0, Position: 6, Length: 1}}
+	if !reflect.DeepEqual(a.Links, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, a.Links)
+	}
+	if a.States[0].State != "scared" {
+		t.Errorf("Failed: expected %v, recieved %v", "scared", a.States[0].State)
+	}
+	a = AnalyzeTopic("I am sad about climate  change", `"climate change"`)
+	expected = []TopicLink{{Kind: PrepositionLink, State: 0, Position: 4, Length: 3}}
+	if !reflect.DeepEqual(a.Links, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, a.Links)
+	}
+}
+
+func TestTopicLinkMultiWordState(t *testing.T) {
+	a := AnalyzeTopic("I am angry at self over covid", "covid")
+	for _, l := range a.Links {
+		if m := a.States[l.State]; m.State != "angry at self" || m.Length != 3 {
+			t.Errorf("Failed: expected %v, recieved %v", "angry at self", m)
+		}
+	}
+	if len(a.Links) != 1 {
+		t.Errorf("Failed: expected %v, recieved %v", 1, len(a.Links))
+	}
+}
+
+func TestDirectedStates(t *testing.T) {
+	type testCase struct {
+		textString string
+		topic      string
+		expected   []string
+	}
+	testCases := []testCase{
+		{textString: "I am scared of covid but happy about my job", topic: "covid", expected: []string{"scared"}},
+		{textString: "I am scared of spiders but happy about covid", topic: "covid", expected: []string{"happy"}},
+		{textString: "I am angry at self over covid", topic: "covid", expected: []string{"angry at self"}},
+		{textString: "I am happy", topic: "covid", expected: []string{}}}
+	for _, tc := range testCases {
+		out := Analyzer{}.DirectedStates(tc.textString, tc.topic)
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestValidTextTowardTopic(t *testing.T) {
+	type testCase struct {
+		textString string
+		topic      string
+		expected   bool
+	}
+	testCases := []testCase{
+		{textString: "covid makes me scared", topic: "covid", expected: true},
+		{textString: "I am scared of covid", topic: "covid", expected: true},
+		{textString: "I'm happy the covid rules ended", topic: "covid", expected: false},
+		{textString: "the kids are scared of covid", topic: "covid", expected: false},
+		{textString: "I am not scared of covid", topic: "covid", expected: true}}
+	for _, tc := range testCases {
+		out := ValidTextTowardTopic(tc.textString, tc.topic)
+		if out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestLinkKindString(t *testing.T) {
+	type testCase struct {
+		kind     LinkKind
+		expected string
+	}
+	testCases := []testCase{
+		{kind: PrepositionLink, expected: "preposition"},
+		{kind: CausativeLink, expected: "causative"},
+		{kind: ProximityLink, expected: "proximity"},
+		{kind: LinkKind(9), expected: "LinkKind(9)"}}
+	for _, tc := range testCases {
+		if out := tc.kind.String(); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v", tc.expected, out)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Attribution of the states to the person who experiences them. Besides the author's own feelings
+("I am sad"), texts describe the feelings of groups ("we are so excited") and of others
+("my mom is scared", "everyone is angry"). The nearest reference before a word in its sentence
+decides its attribution; a sentence with no reference is attributed to the author.
+*/
+
+// Attribution is the grammatical person a state is attributed to.
+type Attribution int
+
+const (
+	// FirstSingular is the author, such as "I am sad".
+	FirstSingular Attribution = iota
+	// FirstPlural is a group including the author, such as "we are so excited".
+	FirstPlural
+	// Second is the addressee, such as "you are angry".
+	Second
+	// Third is someone else, such as "my mom is scared" or "everyone is angry".
+	Third
+)
+
+var attributionNames = map[Attribution]string{
+	FirstSingular: "first-singular",
+	FirstPlural:   "first-plural",
+	Second:        "second",
+	Third:         "third",
+}
+
+// String returns the name of the attribution.
+func (a Attribution) String() string {
+	if name, ok := attributionNames[a]; ok {
+		return name
+	}
+	return fmt.Sprintf("Attribution(%d)", int(a))
+}
+
+// GroupReferences are the references to a group including the author.
+var GroupReferences = []string{"we", "us", "ourselves", "ourself"}
+
+// SecondPersonReferences are the references to the addressee.
+var SecondPersonReferences = []string{"you", "u", "ya", "yourself", "yourselves"}
+
+// ThirdPersonReferences are the references to someone else than the author or the addressee.
+var ThirdPersonReferences = []string{
+	"he", "she", "it", "they", "him", "them", "himself", "herself", "itself", "themselves",
+	"everyone", "everybody", "someone", "somebody", "anyone", "anybody", "nobody", "people",
+}
+
+// PersonNouns are the nouns of people that, after a possessive, refer to someone else than the author ("my mom").
+var PersonNouns = []string{
+	"mom", "mum", "mother", "dad", "father", "parent", "parents", "brother", "sister", "son", "daughter",
+	"kid", "kids", "child", "children", "baby", "husband", "wife", "partner", "boyfriend", "girlfriend",
+	"family", "friend", "friends", "bestie", "boss", "colleague", "colleagues", "coworker", "coworkers",
+	"team", "neighbor", "neighbour", "teacher", "doctor", "grandma", "grandpa", "aunt", "uncle", "cousin",
+}
+
+// possessives are the determiners that, before a person noun, make it a reference ("my mom", "our team").
+var possessives = map[string]bool{"my": true, "our": true, "your": true, "his": true, "her": true, "their": true}
+
+// personNouns are the processed `PersonNouns`.
+var personNouns = func() map[string]bool {
+	res := map[string]bool{}
+	for _, n := range PersonNouns {
+		res[string(text.AppendWord(nil, n))] = true
+	}
+	return res
+}()
+
+// attributionReferences maps every reference to its attribution.
+var attributionReferences = buildAttributionReferences()
+
+func buildAttributionReferences() map[string]Attribution {
+	res := map[string]Attribution{"i": FirstSingular, "me": FirstSingular, "myself": FirstSingular}
+	for _, r := range GroupReferences {
+		res[r] = FirstPlural
+	}
+	for _, r := range SecondPersonReferences {
+		res[r] = Second
+	}
+	for _, r := range ThirdPersonReferences {
+		res[r] = Third
+	}
+	return res
+}
+
+// DetectAttribution returns the attribution of every token of a text.
+// The result is aligned with the output of `text.GenerateValidWords`.
+func DetectAttribution(textString string) []Attribution {
+	return annotateText(textString).Attribution
+}
+
+// appendContractionBase appends to dst the processed part of a raw token before its apostrophe,
+// such as "we" for "We're".
+func appendContractionBase(dst []byte, raw string) []byte {
+	if i := strings.IndexAny(raw, "'’"); i >= 0 {
+		raw = raw[:i]
+	}
+	return text.AppendWord(dst, raw)
+}
@@ -0,0 +1,70 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestDetectAttribution(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []Attribution
+	}
+	cases := []testCase{
+		{textString: "I am sad", expected: []Attribution{FirstSingular, FirstSingular, FirstSingular}},
+		{textString: "we are so excited", expected: []Attribution{FirstPlural, FirstPlural, FirstPlural, FirstPlural}},
+		{textString: "We're excited", expected: []Attribution{FirstPlural, FirstPlural}},
+		{textString: "you look angry", expected: []Attribution{Second, Second, Second}},
+		{textString: "my mom is scared", expected: []Attribution{FirstSingular, Third, Third, Third}},
+		{textString: "my heart is happy", expected: []Attribution{FirstSingular, FirstSingular, FirstSingular, FirstSingular}},
+		{textString: "our team is excited", expected: []Attribution{FirstSingular, Third, Third, Third}},
+		{textString: "everyone is angry. I am calm", expected: []Attribution{Third, Third, Third, FirstSingular, FirstSingular, FirstSingular}},
+		{textString: "it makes me sad", expected: []Attribution{Third, Third, FirstSingular, FirstSingular}}}
+
+	for _, c := range cases {
+		out := DetectAttribution(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+				break
+			}
+		}
+	}
+}
+
+func TestAnalyzerAttributions(t *testing.T) {
+	texts := []string{"I am happy but my mom is scared", "we are excited", "I am calm"}
+	out, err := Analyzer{Attributions: []Attribution{FirstSingular}}.AggregateCategories(texts)
+	if err != nil {
+		t.Fatalf("Failed: unexpected error %v", err)
+	}
+	expected := map[Category]float64{"joviality": 1.0 / 3, "serenity": 1.0 / 3}
+	if len(out) != len(expected) || out["joviality"] != expected["joviality"] || out["serenity"] != expected["serenity"] {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+
+	split, err := Analyzer{}.AggregateByAttribution(texts)
+	if err != nil {
+		t.Fatalf("Failed: unexpected error %v", err)
+	}
+	expectedSplit := map[Attribution]map[Category]float64{
+		FirstSingular: {"joviality": 1.0 / 3, "serenity": 1.0 / 3},
+		FirstPlural:   {"joviality": 1.0 / 3},
+		Third:         {"fear": 1.0 / 3}}
+	if len(split) != len(expectedSplit) {
+		t.Fatalf("Failed: expected %v, recieved %v", expectedSplit, split)
+	}
+	for a, catgs := range expectedSplit {
+		if len(split[a]) != len(catgs) {
+			t.Errorf("Failed: expected %v, recieved %v", expectedSplit, split)
+		}
+		for c, v := range catgs {
+			if split[a][c] != v {
+				t.Errorf("Failed: expected %v, recieved %v", expectedSplit, split)
+			}
+		}
+	}
+}
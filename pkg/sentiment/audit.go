@@ -0,0 +1,193 @@
+package sentiment
+
+import (
+	"bufio"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Phonetic false-positive audit. Everyday words whose phonetic code collides with the code of a state
+("seat" and "sad" are both S300) are counted as that state. The audit runs a word-frequency list through
+an encoder and a lexicon, and reports the colliding words of every state, the most frequent first.
+Its exclusions can be fed back into matching, see `Analyzer.Exclusions`.
+*/
+
+//go:embed data/words.txt
+var bundledWords string
+
+// WordFrequency is an entry of a word-frequency list.
+type WordFrequency struct {
+	Word string
+	// Rank is the 1-based position of the word in the list, the most frequent first.
+	Rank int
+	// Count is the number of occurrences of the word, 0 if the list only provides the ranks.
+	Count int
+}
+
+// BundledWordList returns the bundled word-frequency list: the thousand or so most frequent words of a public-domain
+// English text, Newton's Opticks, with their counts. See the header of data/words.txt for its provenance.
+// Supply a list counted on the analyzed corpus to `ParseWordList` for ranks that fit it.
+func BundledWordList() []WordFrequency {
+	res, _ := ParseWordList(strings.NewReader(bundledWords))
+	return res
+}
+
+// ParseWordList reads a word-frequency list, with a word per line, optionally followed by its count.
+// The words are ranked in decreasing order of their counts, and in their order in the list if there are no counts.
+// Empty lines and the lines starting with '#' are skipped.
+func ParseWordList(r io.Reader) ([]WordFrequency, error) {
+	res := []WordFrequency{}
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		wf := WordFrequency{Word: fields[0]}
+		switch len(fields) {
+		case 1:
+		case 2:
+			count, err := strconv.Atoi(fields[1])
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("line %d: invalid count %q", line, fields[1])
+			}
+			wf.Count = count
+		default:
+			return nil, fmt.Errorf("line %d: expected a word and an optional count, found %d fields", line, len(fields))
+		}
+		res = append(res, wf)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(res, func(i, j int) bool { return res[i].Count > res[j].Count })
+	for i := range res {
+		res[i].Rank = i + 1
+	}
+	return res, nil
+}
+
+// Collision is a word of a word-frequency list whose phonetic code is the code of a lexicon entry.
+type Collision struct {
+	WordFrequency
+	Code string
+}
+
+// StateAudit lists the words colliding with a lexicon entry, the most frequent first.
+type StateAudit struct {
+	State      string
+	Collisions []Collision
+}
+
+// Audit returns the words of the list that collide with the entries of the lexicon (such as `StatesColl`)
+// according to the encoder, for every entry with at least one collision, in the order of the lexicon.
+// The words are processed like the words of a text. A word that is the entry itself is an exact match and not
+// a collision, and the multi-word entries, which are only matched word by word, exactly, have no collisions.
+func Audit(encoder PhoneticEncoder, lexicon []string, words []WordFrequency) []StateAudit {
+	singleWords := []string{}
+	for _, s := range lexicon {
+		if len(strings.Fields(s)) == 1 {
+			singleWords = append(singleWords, s)
+		}
+	}
+	index := BuildIndex(encoder, singleWords)
+	byState := map[string][]Collision{}
+	seen := map[string]bool{}
+	for _, wf := range words {
+		w := string(text.AppendWord(nil, wf.Word))
+		if w == "" || seen[w] {
+			continue
+		}
+		seen[w] = true
+		for _, code := range text.Codes(encoder, w) {
+			for _, s := range index[code] {
+				if w == string(text.AppendWord(nil, s)) || containsCollision(byState[s], w) {
+					continue
+				}
+				c := Collision{WordFrequency: wf, Code: code}
+				c.Word = w
+				byState[s] = append(byState[s], c)
+			}
+		}
+	}
+	res := []StateAudit{}
+	for _, s := range lexicon {
+		if cs := byState[s]; len(cs) > 0 {
+			sort.SliceStable(cs, func(i, j int) bool { return cs[i].Rank < cs[j].Rank })
+			res = append(res, StateAudit{State: s, Collisions: cs})
+			delete(byState, s)
+		}
+	}
+	return res
+}
+
+// AuditStates returns the words of the bundled list that collide with the states of `StatesColl`,
+// according to the default encoder.
+func AuditStates() []StateAudit {
+	return Audit(DefaultEncoder, StatesColl, BundledWordList())
+}
+
+func containsCollision(cs []Collision, word string) bool {
+	for _, c := range cs {
+		if c.Word == word {
+			return true
+		}
+	}
+	return false
+}
+
+// Exclusions returns the colliding words of every state, limited to the words ranked up to maxRank
+// (all of them if maxRank is 0), to be set as `Analyzer.Exclusions`.
+func Exclusions(audits []StateAudit, maxRank int) map[string][]string {
+	res := map[string][]string{}
+	for _, a := range audits {
+		for _, c := range a.Collisions {
+			if maxRank == 0 || c.Rank <= maxRank {
+				res[a.State] = append(res[a.State], c.Word)
+			}
+		}
+	}
+	return res
+}
+
+// WriteExclusions writes the exclusions as a JSON object, mapping every state to its excluded words.
+func WriteExclusions(w io.Writer, exclusions map[string][]string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(exclusions)
+}
+
+// ReadExclusions reads the exclusions written by `WriteExclusions`, for the states of the built-in `Lexicons`.
+// See `Lexicon.ReadExclusions` for the exclusions of a custom lexicon.
+func ReadExclusions(r io.Reader) (map[string][]string, error) {
+	return readExclusions(r, func(state string) bool {
+		for _, l := range Lexicons {
+			if _, ok := l.Categories[state]; ok {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// readExclusions reads the exclusions written by `WriteExclusions`, checking their states with known.
+func readExclusions(r io.Reader, known func(state string) bool) (map[string][]string, error) {
+	res := map[string][]string{}
+	if err := json.NewDecoder(r).Decode(&res); err != nil {
+		return nil, fmt.Errorf("invalid exclusions: %v", err)
+	}
+	for s := range res {
+		if !known(s) {
+			return nil, fmt.Errorf("invalid exclusions: unknown state %q", s)
+		}
+	}
+	return res, nil
+}
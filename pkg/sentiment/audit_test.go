@@ -0,0 +1,104 @@
+package sentiment
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+func TestParseWordList(t *testing.T) {
+	out, err := ParseWordList(strings.NewReader("# comment\nseat 10\n\nsaid 200\nhappy 10\n"))
+	expected := []WordFrequency{{Word: "said", Rank: 1, Count: 200}, {Word: "seat", Rank: 2, Count: 10}, {Word: "happy", Rank: 3, Count: 10}}
+	if err != nil || len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	for i := range out {
+		if out[i] != expected[i] {
+			t.Errorf("Failed: expected %v, recieved %v", expected[i], out[i])
+		}
+	}
+	for _, list := range []string{"seat ten", "seat -1", "seat 1 2"} {
+		if _, err := ParseWordList(strings.NewReader(list)); err == nil {
+			t.Errorf("Failed: expected an error for %q", list)
+		}
+	}
+}
+
+func TestAudit(t *testing.T) {
+	words := []WordFrequency{{Word: "Said", Rank: 1}, {Word: "sad", Rank: 2}, {Word: "Seat", Rank: 3}, {Word: "angry", Rank: 4}, {Word: "answer", Rank: 5}, {Word: "said", Rank: 6}}
+	out := Audit(DefaultEncoder, []string{"sad", "happy", "angry at self"}, words)
+	expected := []StateAudit{
+		{State: "sad", Collisions: []Collision{
+			{WordFrequency: WordFrequency{Word: "said", Rank: 1}, Code: "S300"},
+			{WordFrequency: WordFrequency{Word: "seat", Rank: 3}, Code: "S300"}}}}
+	if len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v", expected, out)
+	}
+	for i := range out {
+		if out[i].State != expected[i].State || len(out[i].Collisions) != len(expected[i].Collisions) {
+			t.Errorf("Failed: expected %v, recieved %v", expected[i], out[i])
+			continue
+		}
+		for j := range out[i].Collisions {
+			if out[i].Collisions[j] != expected[i].Collisions[j] {
+				t.Errorf("Failed: expected %v, recieved %v", expected[i].Collisions[j], out[i].Collisions[j])
+			}
+		}
+	}
+}
+
+func TestAuditStates(t *testing.T) {
+	exclusions := Exclusions(AuditStates(), 0)
+	for state, word := range map[string]string{"sad": "said", "blue": "below", "shy": "see", "tired": "tried"} {
+		if !excludedWord(exclusions[state], word) {
+			t.Errorf("Failed: expected %q in %v, recieved %v", word, state, exclusions[state])
+		}
+	}
+	for state, words := range exclusions {
+		if excludedWord(words, state) {
+			t.Errorf("Failed: expected the exact state %q not to be excluded", state)
+		}
+	}
+	if out := Exclusions(AuditStates(), 100)["shy"]; len(out) != 1 || out[0] != "so" {
+		t.Errorf("Failed: expected %v, recieved %v", []string{"so"}, out)
+	}
+	if words := BundledWordList(); words[0] != (WordFrequency{Word: "the", Rank: 1, Count: 9825}) {
+		t.Errorf("Failed: expected %v, recieved %v", WordFrequency{Word: "the", Rank: 1, Count: 9825}, words[0])
+	}
+}
+
+func TestExclusionsRoundTrip(t *testing.T) {
+	exclusions := map[string][]string{"sad": {"said", "seat"}}
+	var buf bytes.Buffer
+	if err := WriteExclusions(&buf, exclusions); err != nil {
+		t.Fatal(err)
+	}
+	out, err := ReadExclusions(&buf)
+	if err != nil || len(out["sad"]) != 2 || out["sad"][1] != "seat" {
+		t.Errorf("Failed: expected %v, recieved %v, %v", exclusions, out, err)
+	}
+	for _, s := range []string{`{"sadness": ["seat"]}`, `["seat"]`} {
+		if _, err := ReadExclusions(strings.NewReader(s)); err == nil {
+			t.Errorf("Failed: expected an error for %s", s)
+		}
+	}
+}
+
+func TestAnalyzerExclusions(t *testing.T) {
+	words := []WordFrequency{{Word: "seat", Rank: 1, Count: 20}, {Word: "bill", Rank: 2, Count: 10}}
+	an := Analyzer{Exclusions: Exclusions(Audit(DefaultEncoder, StatesColl, words), 0)}
+	cases := map[string]bool{"I am sad": true, "I took a seat": false, "I paid the bill": false}
+	for s, expected := range cases {
+		if !(Analyzer{}).ValidText(s) {
+			t.Errorf("Failed: expected %q to be valid without exclusions", s)
+		}
+		if out := an.ValidText(s); out != expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", expected, out, s)
+		}
+	}
+	if !ContainsValidSentiment(text.GenerateValidWords("I took a seat")) {
+		t.Errorf("Failed: expected the words-collection to match a state")
+	}
+}
@@ -11,7 +11,7 @@ var SelfReferences = []string{"I am", "I'm", "I", "am", "feeling", "me", "myself
 // StatesColl is a collection of all the specific sentiment states that can appear in a text,
 // as recognized by the PANAS-t paper.
 var StatesColl = []string{
-	// jovility states
+	// joviality states
 	"happy", "joyful", "delighted", "cheerful", "excited", "enthusiastic", "lively", "energetic",
 	// selfAssurance states
 	"proud", "strong", "confident", "bold", "daring", "fearless",
@@ -36,128 +36,138 @@ var StatesColl = []string{
 }
 
 // CategoriesMap is map of available sentiment categories, as recognized by the pANAS-t paper
-var CategoriesMap = map[string]bool{
-	"jovility":      true,
-	"selfAssurance": true,
-	"attentiveness": true,
-	"fear":          true,
-	"hostility":     true,
-	"guilt":         true,
-	"sadness":       true,
-	"shyness":       true,
-	"fatigue":       true,
-	"serenity":      true,
-	"surprise":      true,
+var CategoriesMap = map[Category]bool{
+	Joviality:     true,
+	SelfAssurance: true,
+	Attentiveness: true,
+	Fear:          true,
+	Hostility:     true,
+	Guilt:         true,
+	Sadness:       true,
+	Shyness:       true,
+	Fatigue:       true,
+	Serenity:      true,
+	Surprise:      true,
 }
 
 // StateC contains a its specific state-category and its positive/negative direction.
 type StateC struct {
-	Category  string
-	Direction string
+	Category  Category
+	Direction Direction
+	// Weight is the intensity of the state within its category, from 0, excluded, to 1, such as 1 for "furious"
+	// and 0.5 for "irritable". The zero value is no weight, and weighs 1, which is the weight of every state
+	// of the built-in lexicons: a state that must not count is left out of its lexicon rather than weighed 0.
+	Weight float64
+	// Dimensions are the valence and the arousal of the state, nil if they are not rated.
+	Dimensions *Dimensions
 }
 
 // StatesCategories is a map of states and their corresponding categories and overall positive/negative emotion.
 var StatesCategories = map[string]StateC{
-	"happy":                  {Category: "jovility", Direction: "positive"},
-	"joyful":                 {Category: "jovility", Direction: "positive"},
-	"delighted":              {Category: "jovility", Direction: "positive"},
-	"cheerful":               {Category: "jovility", Direction: "positive"},
-	"excited":                {Category: "jovility", Direction: "positive"},
-	"enthusiastic":           {Category: "jovility", Direction: "positive"},
-	"lively":                 {Category: "jovility", Direction: "positive"},
-	"energetic":              {Category: "jovility", Direction: "positive"},
-	"proud":                  {Category: "selfAssurance", Direction: "positive"},
-	"strong":                 {Category: "selfAssurance", Direction: "positive"},
-	"confident":              {Category: "selfAssurance", Direction: "positive"},
-	"bold":                   {Category: "selfAssurance", Direction: "positive"},
-	"daring":                 {Category: "selfAssurance", Direction: "positive"},
-	"fearless":               {Category: "selfAssurance", Direction: "positive"},
-	"alert":                  {Category: "attentiveness", Direction: "positive"},
-	"attentiveness":          {Category: "attentiveness", Direction: "positive"},
-	"concentrating":          {Category: "attentiveness", Direction: "positive"},
-	"determined":             {Category: "attentiveness", Direction: "positive"},
-	"afraid":                 {Category: "fear", Direction: "negative"},
-	"scared":                 {Category: "fear", Direction: "negative"},
-	"frightened":             {Category: "fear", Direction: "negative"},
-	"nervous":                {Category: "fear", Direction: "negative"},
-	"jittery":                {Category: "fear", Direction: "negative"},
-	"shaky":                  {Category: "fear", Direction: "negative"},
-	"angry":                  {Category: "hostility", Direction: "negative"},
-	"hostile":                {Category: "hostility", Direction: "negative"},
-	"irritable":              {Category: "hostility", Direction: "negative"},
-	"scornful":               {Category: "hostility", Direction: "negative"},
-	"disgusted":              {Category: "hostility", Direction: "negative"},
-	"loathing":               {Category: "hostility", Direction: "negative"},
-	"guilty":                 {Category: "guilt", Direction: "negative"},
-	"ashamed":                {Category: "guilt", Direction: "negative"},
-	"blameworthy":            {Category: "guilt", Direction: "negative"},
-	"angry at self":          {Category: "guilt", Direction: "negative"},
-	"disgusted with self":    {Category: "guilt", Direction: "negative"},
-	"dissatisfied with self": {Category: "guilt", Direction: "negative"},
-	"sad":                    {Category: "sadness", Direction: "negative"},
-	"blue":                   {Category: "sadness", Direction: "negative"},
-	"downhearted":            {Category: "sadness", Direction: "negative"},
-	"alone":                  {Category: "sadness", Direction: "negative"},
-	"lonely":                 {Category: "sadness", Direction: "negative"},
-	"shy":                    {Category: "shyness", Direction: "other"},
-	"bashful":                {Category: "shyness", Direction: "other"},
-	"sheepish":               {Category: "shyness", Direction: "other"},
-	"timid":                  {Category: "shyness", Direction: "other"},
-	"sleepy":                 {Category: "fatigue", Direction: "other"},
-	"tired":                  {Category: "fatigue", Direction: "other"},
-	"sluggish":               {Category: "fatigue", Direction: "other"},
-	"drowsy":                 {Category: "fatigue", Direction: "other"},
-	"calm":                   {Category: "serenity", Direction: "other"},
-	"relaxed":                {Category: "serenity", Direction: "other"},
-	"at ease":                {Category: "serenity", Direction: "other"},
-	"amazed":                 {Category: "surprise", Direction: "other"},
-	"surprised":              {Category: "surprise", Direction: "other"},
-	"astonished":             {Category: "surprise", Direction: "other"},
+	"happy":                  {Category: Joviality, Direction: Positive},
+	"joyful":                 {Category: Joviality, Direction: Positive},
+	"delighted":              {Category: Joviality, Direction: Positive},
+	"cheerful":               {Category: Joviality, Direction: Positive},
+	"excited":                {Category: Joviality, Direction: Positive},
+	"enthusiastic":           {Category: Joviality, Direction: Positive},
+	"lively":                 {Category: Joviality, Direction: Positive},
+	"energetic":              {Category: Joviality, Direction: Positive},
+	"proud":                  {Category: SelfAssurance, Direction: Positive},
+	"strong":                 {Category: SelfAssurance, Direction: Positive},
+	"confident":              {Category: SelfAssurance, Direction: Positive},
+	"bold":                   {Category: SelfAssurance, Direction: Positive},
+	"daring":                 {Category: SelfAssurance, Direction: Positive},
+	"fearless":               {Category: SelfAssurance, Direction: Positive},
+	"alert":                  {Category: Attentiveness, Direction: Positive},
+	"attentiveness":          {Category: Attentiveness, Direction: Positive},
+	"concentrating":          {Category: Attentiveness, Direction: Positive},
+	"determined":             {Category: Attentiveness, Direction: Positive},
+	"afraid":                 {Category: Fear, Direction: Negative},
+	"scared":                 {Category: Fear, Direction: Negative},
+	"frightened":             {Category: Fear, Direction: Negative},
+	"nervous":                {Category: Fear, Direction: Negative},
+	"jittery":                {Category: Fear, Direction: Negative},
+	"shaky":                  {Category: Fear, Direction: Negative},
+	"angry":                  {Category: Hostility, Direction: Negative},
+	"hostile":                {Category: Hostility, Direction: Negative},
+	"irritable":              {Category: Hostility, Direction: Negative},
+	"scornful":               {Category: Hostility, Direction: Negative},
+	"disgusted":              {Category: Hostility, Direction: Negative},
+	"loathing":               {Category: Hostility, Direction: Negative},
+	"guilty":                 {Category: Guilt, Direction: Negative},
+	"ashamed":                {Category: Guilt, Direction: Negative},
+	"blameworthy":            {Category: Guilt, Direction: Negative},
+	"angry at self":          {Category: Guilt, Direction: Negative},
+	"disgusted with self":    {Category: Guilt, Direction: Negative},
+	"dissatisfied with self": {Category: Guilt, Direction: Negative},
+	"sad":                    {Category: Sadness, Direction: Negative},
+	"blue":                   {Category: Sadness, Direction: Negative},
+	"downhearted":            {Category: Sadness, Direction: Negative},
+	"alone":                  {Category: Sadness, Direction: Negative},
+	"lonely":                 {Category: Sadness, Direction: Negative},
+	"shy":                    {Category: Shyness, Direction: Other},
+	"bashful":                {Category: Shyness, Direction: Other},
+	"sheepish":               {Category: Shyness, Direction: Other},
+	"timid":                  {Category: Shyness, Direction: Other},
+	"sleepy":                 {Category: Fatigue, Direction: Other},
+	"tired":                  {Category: Fatigue, Direction: Other},
+	"sluggish":               {Category: Fatigue, Direction: Other},
+	"drowsy":                 {Category: Fatigue, Direction: Other},
+	"calm":                   {Category: Serenity, Direction: Other},
+	"relaxed":                {Category: Serenity, Direction: Other},
+	"at ease":                {Category: Serenity, Direction: Other},
+	"amazed":                 {Category: Surprise, Direction: Other},
+	"surprised":              {Category: Surprise, Direction: Other},
+	"astonished":             {Category: Surprise, Direction: Other},
 }
 
 // GeneralPosNegStates is a map of general states and their corresponding categories and overall positive/negative emotion.
 var GeneralPosNegStates = map[string]StateC{
-	"active":       {Category: "general", Direction: "positive"},
-	"alert":        {Category: "general", Direction: "positive"},
-	"attentive":    {Category: "general", Direction: "positive"},
-	"determined":   {Category: "general", Direction: "positive"},
-	"enthusiastic": {Category: "general", Direction: "positive"},
-	"excited":      {Category: "general", Direction: "positive"},
-	"inspired":     {Category: "general", Direction: "positive"},
-	"interested":   {Category: "general", Direction: "positive"},
-	"proud":        {Category: "general", Direction: "positive"},
-	"strong":       {Category: "general", Direction: "positive"},
-	"afraid":       {Category: "general", Direction: "negative"},
-	"scared":       {Category: "general", Direction: "negative"},
-	"nervous":      {Category: "general", Direction: "negative"},
-	"jittery":      {Category: "general", Direction: "negative"},
-	"irritable":    {Category: "general", Direction: "negative"},
-	"hostile":      {Category: "general", Direction: "negative"},
-	"guilty":       {Category: "general", Direction: "negative"},
-	"ashamed":      {Category: "general", Direction: "negative"},
-	"upset":        {Category: "general", Direction: "negative"},
-	"distressed":   {Category: "general", Direction: "negative"},
+	"active":       {Category: General, Direction: Positive},
+	"alert":        {Category: General, Direction: Positive},
+	"attentive":    {Category: General, Direction: Positive},
+	"determined":   {Category: General, Direction: Positive},
+	"enthusiastic": {Category: General, Direction: Positive},
+	"excited":      {Category: General, Direction: Positive},
+	"inspired":     {Category: General, Direction: Positive},
+	"interested":   {Category: General, Direction: Positive},
+	"proud":        {Category: General, Direction: Positive},
+	"strong":       {Category: General, Direction: Positive},
+	"afraid":       {Category: General, Direction: Negative},
+	"scared":       {Category: General, Direction: Negative},
+	"nervous":      {Category: General, Direction: Negative},
+	"jittery":      {Category: General, Direction: Negative},
+	"irritable":    {Category: General, Direction: Negative},
+	"hostile":      {Category: General, Direction: Negative},
+	"guilty":       {Category: General, Direction: Negative},
+	"ashamed":      {Category: General, Direction: Negative},
+	"upset":        {Category: General, Direction: Negative},
+	"distressed":   {Category: General, Direction: Negative},
 }
 
 // WorldBaseline is based on about 3.5 years (2009-13) of twitter data, about 0.48bn tweets.
 // Please see the paper for further details.
-var WorldBaseline = map[string]float64{
+var WorldBaseline = map[Category]float64{
 	// positive sentiments
-	"joviality":     0.0182421,
-	"selfAssurance": 0.0036012,
-	"attentiveness": 0.0008997,
+	Joviality:     0.0182421,
+	SelfAssurance: 0.0036012,
+	Attentiveness: 0.0008997,
 	// negative sentiments
-	"fear":      0.0063791,
-	"hostility": 0.0018225,
-	"guilt":     0.0021756,
-	"sadness":   0.0086279,
+	Fear:      0.0063791,
+	Hostility: 0.0018225,
+	Guilt:     0.0021756,
+	Sadness:   0.0086279,
 	// other sentiments
-	"shyness":  0.0007608,
-	"fatigue":  0.0240757,
-	"surprise": 0.0084612,
-	"serenity": 0.0022914,
-	// overall (computed as the weighted averages of the positive, negative, and other sentiment values)
-	"positive": 0.007581,
-	"negative": 0.004751275,
-	"other":    0.008897275,
+	Shyness:  0.0007608,
+	Fatigue:  0.0240757,
+	Surprise: 0.0084612,
+	Serenity: 0.0022914,
+}
+
+// WorldDirectionBaseline is the overall sentiment of every direction in the data of `WorldBaseline`,
+// computed as the weighted averages of the positive, negative, and other sentiment values.
+var WorldDirectionBaseline = map[Direction]float64{
+	Positive: 0.007581,
+	Negative: 0.004751275,
+	Other:    0.008897275,
 }
@@ -0,0 +1,188 @@
+package sentiment
+
+import (
+	"fmt"
+	"sync"
+)
+
+/*
+Typed categories and directions. The categories and directions of the lexicons are checked against
+the registries below, so that a misspelled category is rejected by `NewLexicon` rather than silently
+counted apart. The former "jovility" spelling of the joviality category is still accepted when parsing.
+The text and JSON encodings of a category only accept the known categories, and the custom categories
+of the lexicons registered with `Lexicon.RegisterCategories`.
+
+Categories form a hierarchy: the states of a lexicon roll up to their category, the categories to their
+direction and the directions to the overall sentiment, see `Rollup`. A lexicon can add domain categories
+next to the built-in ones, see `LexiconOptions.Categories`.
+*/
+
+// Category is the sentiment category of a state, such as joviality or fatigue.
+type Category string
+
+// The categories of the PANAS-t paper, and the category of the items that only belong to the general dimension scales.
+const (
+	Joviality     Category = "joviality"
+	SelfAssurance Category = "selfAssurance"
+	Attentiveness Category = "attentiveness"
+	Fear          Category = "fear"
+	Hostility     Category = "hostility"
+	Guilt         Category = "guilt"
+	Sadness       Category = "sadness"
+	Shyness       Category = "shyness"
+	Fatigue       Category = "fatigue"
+	Serenity      Category = "serenity"
+	Surprise      Category = "surprise"
+	General       Category = "general"
+)
+
+// KnownCategories are the built-in categories, the 11 PANAS-t categories first, in the order of their states
+// in `StatesColl`, and the general category last. See `Lexicon.AllCategories` for the custom ones.
+var KnownCategories = []Category{
+	Joviality, SelfAssurance, Attentiveness, Fear, Hostility, Guilt, Sadness, Shyness, Fatigue, Serenity, Surprise,
+	General,
+}
+
+// categoryAliases maps the alternative names accepted by `ParseCategory` to their category.
+var categoryAliases = map[string]Category{
+	"jovility": Joviality,
+}
+
+// categoryDirections maps every built-in category to its direction. The general category has none,
+// its states being either positive or negative.
+var categoryDirections = map[Category]Direction{
+	Joviality:     Positive,
+	SelfAssurance: Positive,
+	Attentiveness: Positive,
+	Fear:          Negative,
+	Hostility:     Negative,
+	Guilt:         Negative,
+	Sadness:       Negative,
+	Shyness:       Other,
+	Fatigue:       Other,
+	Serenity:      Other,
+	Surprise:      Other,
+	General:       "",
+}
+
+// String returns the name of the category.
+func (c Category) String() string {
+	return string(c)
+}
+
+// Valid returns true if the category is one of `KnownCategories`. The custom categories of a lexicon
+// are only valid in their lexicon, see `Lexicon.ValidCategory`.
+func (c Category) Valid() bool {
+	_, ok := categoryDirections[c]
+	return ok
+}
+
+// Direction returns the direction under which the category rolls up. It returns false for the unknown
+// categories and for the general category, whose states roll up under their own direction.
+// See `Lexicon.CategoryDirection` for the custom categories of a lexicon.
+func (c Category) Direction() (Direction, bool) {
+	d := categoryDirections[c]
+	return d, d != ""
+}
+
+// ParseCategory returns the category with the supplied name, as returned by `String`, or with one of its aliases.
+func ParseCategory(name string) (Category, error) {
+	if c := Category(name); c.Valid() {
+		return c, nil
+	}
+	if c, ok := categoryAliases[name]; ok {
+		return c, nil
+	}
+	return "", fmt.Errorf("unknown category %q", name)
+}
+
+// registeredCategories counts the registrations of the custom categories, see `Lexicon.RegisterCategories`.
+var registeredCategories = struct {
+	sync.RWMutex
+	counts map[Category]int
+}{counts: map[Category]int{}}
+
+// registered returns true if the category is a custom category of a registered lexicon.
+func (c Category) registered() bool {
+	registeredCategories.RLock()
+	defer registeredCategories.RUnlock()
+	return registeredCategories.counts[c] > 0
+}
+
+// MarshalText encodes the category as its name. It rejects the categories that are neither one of
+// `KnownCategories` nor a custom category of a registered lexicon, see `Lexicon.RegisterCategories`.
+func (c Category) MarshalText() ([]byte, error) {
+	if !c.Valid() && !c.registered() {
+		return nil, fmt.Errorf("unknown category %q", string(c))
+	}
+	return []byte(c), nil
+}
+
+// UnmarshalText decodes a category from its name, or from one of the aliases of `ParseCategory`.
+// It rejects the names that are neither known nor a custom category of a registered lexicon, see `Lexicon.RegisterCategories`.
+func (c *Category) UnmarshalText(b []byte) error {
+	parsed, err := ParseCategory(string(b))
+	if err != nil {
+		if !Category(b).registered() {
+			return err
+		}
+		parsed = Category(b)
+	}
+	*c = parsed
+	return nil
+}
+
+// Direction is the positive, negative or other direction of a state.
+type Direction string
+
+// The directions.
+const (
+	Positive Direction = "positive"
+	Negative Direction = "negative"
+	// Other is the direction of the states that are neither positive nor negative, such as the fatigue ones.
+	Other Direction = "other"
+)
+
+// KnownDirections are all the directions.
+var KnownDirections = []Direction{Positive, Negative, Other}
+
+// String returns the name of the direction.
+func (d Direction) String() string {
+	return string(d)
+}
+
+// Valid returns true if the direction is one of `KnownDirections`.
+func (d Direction) Valid() bool {
+	for _, k := range KnownDirections {
+		if d == k {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseDirection returns the direction with the supplied name, as returned by `String`.
+func ParseDirection(name string) (Direction, error) {
+	if d := Direction(name); d.Valid() {
+		return d, nil
+	}
+	return "", fmt.Errorf("unknown direction %q", name)
+}
+
+// MarshalText encodes the direction as its name.
+func (d Direction) MarshalText() ([]byte, error) {
+	if !d.Valid() {
+		return nil, fmt.Errorf("unknown direction %q", string(d))
+	}
+	return []byte(d), nil
+}
+
+// UnmarshalText decodes a direction from its name, see `ParseDirection`.
+func (d *Direction) UnmarshalText(b []byte) error {
+	parsed, err := ParseDirection(string(b))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,164 @@
+package sentiment
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseCategory(t *testing.T) {
+	type testCase struct {
+		name     string
+		expected Category
+		err      bool
+	}
+	testCases := []testCase{
+		{name: "joviality", expected: Joviality},
+		{name: "jovility", expected: Joviality},
+		{name: "selfAssurance", expected: SelfAssurance},
+		{name: "general", expected: General},
+		{name: "joy", err: true},
+		{name: "", err: true}}
+	for _, tc := range testCases {
+		out, err := ParseCategory(tc.name)
+		if out != tc.expected || (err != nil) != tc.err {
+			t.Errorf("Failed: expected %v, recieved %v, %v", tc.expected, out, err)
+		}
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	type testCase struct {
+		name     string
+		expected Direction
+		err      bool
+	}
+	testCases := []testCase{
+		{name: "positive", expected: Positive},
+		{name: "negative", expected: Negative},
+		{name: "other", expected: Other},
+		{name: "postive", err: true}}
+	for _, tc := range testCases {
+		out, err := ParseDirection(tc.name)
+		if out != tc.expected || (err != nil) != tc.err {
+			t.Errorf("Failed: expected %v, recieved %v, %v", tc.expected, out, err)
+		}
+	}
+}
+
+func TestCategoryJSON(t *testing.T) {
+	type entry struct {
+		Category  Category
+		Direction Direction
+	}
+	b, err := json.Marshal(entry{Category: Joviality, Direction: Positive})
+	if err != nil || string(b) != `{"Category":"joviality","Direction":"positive"}` {
+		t.Errorf("Failed: expected %v, recieved %v, %v", `{"Category":"joviality","Direction":"positive"}`, string(b), err)
+	}
+	var out entry
+	if err := json.Unmarshal([]byte(`{"Category":"jovility","Direction":"negative"}`), &out); err != nil || out != (entry{Category: Joviality, Direction: Negative}) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", entry{Category: Joviality, Direction: Negative}, out, err)
+	}
+	if err := json.Unmarshal([]byte(`{"Category":"burnout"}`), &out); err == nil {
+		t.Errorf("Failed: expected an error for an unregistered category")
+	}
+	if _, err := json.Marshal(map[Category]float64{"burnout": 1}); err == nil {
+		t.Errorf("Failed: expected an error for an unregistered category")
+	}
+	l := mustLexicon("work", nil, nil, LexiconOptions{Categories: map[Category]Direction{"burnout": Other}})
+	unregister := l.RegisterCategories()
+	if err := json.Unmarshal([]byte(`{"Category":"burnout"}`), &out); err != nil || out.Category != "burnout" {
+		t.Errorf("Failed: expected %v, recieved %v, %v", "burnout", out.Category, err)
+	}
+	if b, err := json.Marshal(map[Category]float64{"burnout": 1}); err != nil || string(b) != `{"burnout":1}` {
+		t.Errorf("Failed: expected %v, recieved %v, %v", `{"burnout":1}`, string(b), err)
+	}
+	unregister()
+	unregister()
+	if err := json.Unmarshal([]byte(`{"Category":"burnout"}`), &out); err == nil {
+		t.Errorf("Failed: expected an error for an unregistered category")
+	}
+	if err := json.Unmarshal([]byte(`{"Category":""}`), &out); err == nil {
+		t.Errorf("Failed: expected an error for an empty category")
+	}
+	if _, err := json.Marshal(entry{Category: "", Direction: Positive}); err == nil {
+		t.Errorf("Failed: expected an error for an empty category")
+	}
+	if _, err := json.Marshal(entry{Category: "joy", Direction: Positive}); err == nil {
+		t.Errorf("Failed: expected an error for an unknown category")
+	}
+	if _, err := json.Marshal(entry{Category: Joviality, Direction: "postive"}); err == nil {
+		t.Errorf("Failed: expected an error for an unknown direction")
+	}
+}
+
+func TestKnownCategories(t *testing.T) {
+	for _, l := range Lexicons {
+		for s, sc := range l.Categories {
+			if !sc.Category.Valid() || !sc.Direction.Valid() {
+				t.Errorf("Failed: expected a known category and direction for %q in %v, recieved %v", s, l.Name, sc)
+			}
+		}
+	}
+	for c := range CategoriesMap {
+		if _, ok := WorldBaseline[c]; !ok {
+			t.Errorf("Failed: expected a world baseline for %v", c)
+		}
+	}
+	for _, d := range KnownDirections {
+		if _, ok := WorldDirectionBaseline[d]; !ok {
+			t.Errorf("Failed: expected a world baseline for %v", d)
+		}
+	}
+}
+
+func TestLexiconCategories(t *testing.T) {
+	type testCase struct {
+		categories map[Category]Direction
+		err        bool
+	}
+	testCases := []testCase{
+		{categories: map[Category]Direction{"burnout": Other, "gratitude": Positive}},
+		{categories: map[Category]Direction{"gratitude": "grateful"}, err: true},
+		{categories: map[Category]Direction{"jovility": Positive}, err: true},
+		{categories: map[Category]Direction{Fear: Positive}, err: true},
+		{categories: map[Category]Direction{"": Positive}, err: true}}
+	for _, tc := range testCases {
+		if _, err := NewLexiconWith("work", nil, nil, LexiconOptions{Categories: tc.categories}); (err != nil) != tc.err {
+			t.Errorf("Failed: expected an error %v for %v, recieved %v", tc.err, tc.categories, err)
+		}
+	}
+	l := mustLexicon("work", []string{"exhausted"}, map[string]StateC{"exhausted": {Category: "burnout", Direction: Other}},
+		LexiconOptions{Categories: map[Category]Direction{"burnout": Other, "gratitude": Positive}})
+	if d, ok := l.CategoryDirection("burnout"); !ok || d != Other {
+		t.Errorf("Failed: expected %v, recieved %v", Other, d)
+	}
+	if d, ok := l.CategoryDirection(Fear); !ok || d != Negative {
+		t.Errorf("Failed: expected %v, recieved %v", Negative, d)
+	}
+	if _, ok := l.CategoryDirection(General); ok {
+		t.Errorf("Failed: expected no direction for %v", General)
+	}
+	if Category("burnout").Valid() || !l.ValidCategory("burnout") || PANASt.ValidCategory("burnout") {
+		t.Errorf("Failed: expected %v to only be valid in its lexicon", "burnout")
+	}
+	if c, err := l.ParseCategory("burnout"); err != nil || c != "burnout" {
+		t.Errorf("Failed: expected %v, recieved %v, %v", "burnout", c, err)
+	}
+	if c, err := l.ParseCategory("jovility"); err != nil || c != Joviality {
+		t.Errorf("Failed: expected %v, recieved %v, %v", Joviality, c, err)
+	}
+	if _, err := PANASt.ParseCategory("burnout"); err == nil {
+		t.Errorf("Failed: expected an error for a category of another lexicon")
+	}
+	all := l.AllCategories()
+	if len(all) != len(KnownCategories)+2 || all[0] != Joviality || all[len(all)-2] != "burnout" || all[len(all)-1] != "gratitude" {
+		t.Errorf("Failed: expected the custom categories after %v, recieved %v", KnownCategories, all)
+	}
+	if _, err := NewLexicon("work", []string{"exhausted"}, map[string]StateC{"exhausted": {Category: "burnout", Direction: Other}}); err == nil {
+		t.Errorf("Failed: expected an error for a category of another lexicon")
+	}
+	if _, err := NewLexiconWith("work", []string{"exhausted"}, map[string]StateC{"exhausted": {Category: "burnout", Direction: Negative}},
+		LexiconOptions{Categories: map[Category]Direction{"burnout": Other}}); err == nil {
+		t.Errorf("Failed: expected an error for a direction that differs from the one of its category")
+	}
+}
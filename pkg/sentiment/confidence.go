@@ -0,0 +1,142 @@
+package sentiment
+
+import (
+	"fmt"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Match confidence. An exact "happy" and a Soundex hit on "hippo" are not equally certain: every match
+has a kind, decided by how its word relates to the matched entry, and a confidence from 0 to 1, decided
+by its kind, by the context rules of the entry (see `ContextRules`) and by the modality of its clause.
+Both are computed once, when the match is found.
+*/
+
+// MatchKind is how the word of a match relates to the matched entry.
+type MatchKind int
+
+const (
+	// ExactMatch is a word equal to the entry, or the words of a multi-word entry.
+	ExactMatch MatchKind = iota
+	// StemmedMatch is an inflection of the entry ("surprise" for "surprised").
+	StemmedMatch
+	// FuzzyMatch is a word within one edit of the entry, two for entries of eight letters or more,
+	// such as a typo ("hapy" for "happy").
+	FuzzyMatch
+	// PhoneticMatch is a word that only shares the phonetic code of the entry ("hippo" for "happy").
+	PhoneticMatch
+)
+
+var matchKindNames = map[MatchKind]string{
+	ExactMatch:    "exact",
+	StemmedMatch:  "stemmed",
+	FuzzyMatch:    "fuzzy",
+	PhoneticMatch: "phonetic",
+}
+
+// String returns the name of the match kind.
+func (k MatchKind) String() string {
+	if name, ok := matchKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("MatchKind(%d)", int(k))
+}
+
+// matchKindConfidence is the confidence of every match kind. The confidence of a phonetic match
+// grows from 0.3 to 0.7 with the similarity of its word to the entry.
+var matchKindConfidence = map[MatchKind]float64{
+	ExactMatch:    1,
+	StemmedMatch:  0.9,
+	FuzzyMatch:    0.8,
+	PhoneticMatch: 0.3,
+}
+
+// modalityConfidence is the factor applied to the confidence of the states of non-assertive clauses.
+var modalityConfidence = map[Modality]float64{
+	Assertive:     1,
+	Interrogative: 0.5,
+	Conditional:   0.6,
+	Modal:         0.7,
+	Wish:          0.6,
+}
+
+// ambiguousConfidence is the factor applied to the confidence of the states whose exclusion context rules
+// nearly apply, that is would apply with twice their window, such as "blue" a few words away from "sky".
+const ambiguousConfidence = 0.9
+
+// classify returns the kind and the confidence of a hit, based on its kind. An exact hit covers all the words
+// of its pattern; the other ones are classified by comparing their word with the processed words of the pattern,
+// so that "angry" is not an exact match of "angry at self".
+func (m *Matcher) classify(h Hit) (MatchKind, float64) {
+	if h.Exact {
+		return ExactMatch, matchKindConfidence[ExactMatch]
+	}
+	kind, similarity := classifyMatch(h.Word, m.keys[h.Pattern])
+	if kind == PhoneticMatch {
+		return kind, matchKindConfidence[kind] + 0.4*similarity
+	}
+	return kind, matchKindConfidence[kind]
+}
+
+// classifyMatch returns the kind of the match of a processed word with the concatenated processed words of an entry,
+// along with the similarity of the word to the entry.
+func classifyMatch(word, key string) (MatchKind, float64) {
+	if word == key {
+		return ExactMatch, 1
+	}
+	distance, longer := text.Levenshtein(word, key), len(word)
+	if len(key) > longer {
+		longer = len(key)
+	}
+	similarity := 1 - float64(distance)/float64(longer)
+	maxDistance := 1
+	if len(key) >= 8 {
+		maxDistance = 2
+	}
+	switch {
+	case text.SameStem(word, key):
+		return StemmedMatch, similarity
+	case distance <= maxDistance:
+		return FuzzyMatch, similarity
+	}
+	return PhoneticMatch, similarity
+}
+
+// ambiguity returns the factor applied to the confidence of a match covering words[position:position+length]:
+// `ambiguousConfidence` if an exclusion rule would apply with twice its window, and 1 otherwise.
+func (rs ContextRules) ambiguity(words []string, position, length int) float64 {
+	for _, r := range rs.Exclude {
+		if r.Window <= 0 {
+			r.Window = defaultContextWindow
+		}
+		r.Window *= 2
+		if r.applies(words, position, length) {
+			return ambiguousConfidence
+		}
+	}
+	return 1
+}
+
+// Confidence returns the confidence that the analysis is valid according to the analyzer: the product
+// of the confidences of its most certain counted self reference and of its most certain counted state.
+// It is 0 if the analysis has no counted self reference or no counted state.
+func (an Analyzer) Confidence(a Analysis) float64 {
+	selfRef, state := 0.0, 0.0
+	for _, m := range a.SelfRefs {
+		if m.Confidence > selfRef && an.countsSelfRef(m) {
+			selfRef = m.Confidence
+		}
+	}
+	for _, m := range a.States {
+		if m.Confidence > state && an.countsState(m) {
+			state = m.Confidence
+		}
+	}
+	return selfRef * state
+}
+
+// TextConfidence returns the confidence that the text is valid, see `Analyzer.Confidence`.
+func TextConfidence(textString string) float64 {
+	return Analyzer{}.Confidence(Analyze(textString))
+}
@@ -0,0 +1,119 @@
+package sentiment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+func TestStateMatchConfidence(t *testing.T) {
+	type testCase struct {
+		textString string
+		state      string
+		kind       MatchKind
+		confidence float64
+	}
+	cases := []testCase{
+		{textString: "I am happy", state: "happy", kind: ExactMatch, confidence: 1},
+		{textString: "am I happy?", state: "happy", kind: ExactMatch, confidence: 0.5},
+		{textString: "I am surprise", state: "surprised", kind: StemmedMatch, confidence: 0.9},
+		{textString: "I am hapy", state: "happy", kind: FuzzyMatch, confidence: 0.8},
+		{textString: "I am enthusiastc", state: "enthusiastic", kind: FuzzyMatch, confidence: 0.8},
+		{textString: "me and my hippo", state: "happy", kind: PhoneticMatch, confidence: 0.54},
+		{textString: "I feel blue", state: "blue", kind: ExactMatch, confidence: 1},
+		{textString: "I feel blue today and the sky is grey", state: "blue", kind: ExactMatch, confidence: 0.9},
+		{textString: "I am angry at self", state: "angry at self", kind: ExactMatch, confidence: 1}}
+
+	for _, c := range cases {
+		var match *StateMatch
+		a := Analyze(c.textString)
+		for i := range a.States {
+			if a.States[i].State == c.state {
+				match = &a.States[i]
+			}
+		}
+		if match == nil {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.state, a.States, c.textString)
+			continue
+		}
+		if match.Kind != c.kind {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.kind, match.Kind, c.textString)
+		}
+		if math.Abs(match.Confidence-c.confidence) > 1e-9 {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.confidence, match.Confidence, c.textString)
+		}
+	}
+}
+
+func TestSelfRefMatchConfidence(t *testing.T) {
+	type testCase struct {
+		word       string
+		selfRef    string
+		kind       MatchKind
+		confidence float64
+	}
+	cases := []testCase{
+		{word: "I'm", selfRef: "I'm", kind: ExactMatch, confidence: 1},
+		{word: "I", selfRef: "I", kind: ExactMatch, confidence: 1},
+		{word: "myslf", selfRef: "myself", kind: FuzzyMatch, confidence: 0.8}}
+
+	for _, c := range cases {
+		found := false
+		for _, m := range MatchSelfRefs(text.GenerateValidWords(c.word)) {
+			if m.SelfRef != c.selfRef {
+				continue
+			}
+			found = true
+			if m.Kind != c.kind || math.Abs(m.Confidence-c.confidence) > 1e-9 {
+				t.Errorf("Failed: expected %v %v, recieved %v %v for %q", c.kind, c.confidence, m.Kind, m.Confidence, c.word)
+			}
+		}
+		if !found {
+			t.Errorf("Failed: expected %v, recieved no match for %q", c.selfRef, c.word)
+		}
+	}
+}
+
+func TestTextConfidence(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   float64
+	}
+	cases := []testCase{
+		{textString: "", expected: 0},
+		{textString: "happy", expected: 0},
+		{textString: "I am happy", expected: 1},
+		{textString: "am I happy?", expected: 0.5},
+		{textString: "I am hapy and hippo", expected: 0.8},
+		{textString: "me and my hippo", expected: 0.54}}
+
+	for _, c := range cases {
+		if out := TextConfidence(c.textString); math.Abs(out-c.expected) > 1e-9 {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+		}
+	}
+}
+
+func TestAnalyzerMinConfidence(t *testing.T) {
+	an := Analyzer{MinConfidence: 0.75}
+	cases := map[string]bool{"I am happy": true, "I am hapy": true, "me and my hippo": false, "am I happy?": false}
+	for s, expected := range cases {
+		if out := an.ValidText(s); out != expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", expected, out, s)
+		}
+	}
+	if out := an.Categories("I am hapy, not a hippo, just shy"); len(out) != 2 {
+		t.Errorf("Failed: expected 2 categories, recieved %v", out)
+	}
+	out, err := an.AggregateCategories([]string{"I am happy", "me and my hippo"})
+	if err != nil || len(out) != 1 || out["joviality"] != 0.5 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", map[Category]float64{"joviality": 0.5}, out, err)
+	}
+}
+
+func TestMatchKindString(t *testing.T) {
+	if ExactMatch.String() != "exact" || PhoneticMatch.String() != "phonetic" || MatchKind(7).String() != "MatchKind(7)" {
+		t.Errorf("Failed: recieved %v, %v, %v", ExactMatch, PhoneticMatch, MatchKind(7))
+	}
+}
@@ -0,0 +1,237 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Conflict resolution for texts that express states of more than one category.
+The PANAS-t paper counts such texts in every detected category, which remains the default (`CountAll`).
+The same resolution is used for the per-text classification and for the corpus aggregation,
+so that the fractions computed by `AggregateCategories` always agree with `CategoryWeights`.
+*/
+
+// ConflictStrategy decides how a text with states of several categories is counted.
+type ConflictStrategy int
+
+const (
+	// CountAll counts the text once in every detected category.
+	CountAll ConflictStrategy = iota
+	// FirstMatch counts the text only in the category of its first state match.
+	FirstMatch
+	// Dominant counts the text only in the category with the highest weight: the sum of the weights of its
+	// state matches, which is their number unless the analyzer is weighted, see `Analyzer.Weighted`.
+	// Ties are broken in favour of the category with the most state matches, then of the one that appears first.
+	Dominant
+	// DropConflicting drops texts that express both positive and negative states,
+	// and counts the remaining ones in every detected category.
+	DropConflicting
+	// Fractional splits the text evenly across its detected categories, 1/n each.
+	Fractional
+)
+
+var conflictStrategyNames = map[ConflictStrategy]string{
+	CountAll:        "count-all",
+	FirstMatch:      "first-match",
+	Dominant:        "dominant",
+	DropConflicting: "drop-conflicting",
+	Fractional:      "fractional",
+}
+
+// String returns the name of the strategy.
+func (s ConflictStrategy) String() string {
+	if name, ok := conflictStrategyNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("ConflictStrategy(%d)", int(s))
+}
+
+// ParseConflictStrategy returns the strategy with the supplied name, as returned by `String`.
+func ParseConflictStrategy(name string) (ConflictStrategy, error) {
+	for s, n := range conflictStrategyNames {
+		if n == name {
+			return s, nil
+		}
+	}
+	return CountAll, fmt.Errorf("unknown conflict strategy %q", name)
+}
+
+// ResolveCategories applies the strategy to the state matches of a single text and returns
+// the weight with which the text counts in each category. Categories with no weight are omitted.
+func ResolveCategories(matches []StateMatch, strategy ConflictStrategy) map[Category]float64 {
+	var r resolver
+	res := map[Category]float64{}
+	r.addTo(res, matches, Analyzer{Strategy: strategy})
+	return res
+}
+
+// resolver applies the conflict strategies, reusing its buffers between texts.
+type resolver struct {
+	// order are the categories in the order of their first appearance, with their number of matches in counts,
+	// the sum of the weights of their matches in totals and the weight of their most intense state in intensities,
+	// or the sum of the weights of their distinct states if the analyzer sums them, see `Analyzer.SumWeights`.
+	order       []Category
+	counts      []int
+	totals      []float64
+	intensities []float64
+}
+
+// addTo adds to sums the weight with which a text, with the supplied state matches, counts in each category,
+// resolved with the analyzer's strategy. The weight of every category is scaled by the one of its states,
+// see `Analyzer.Weighted` and `Analyzer.SumWeights`.
+func (r *resolver) addTo(sums map[Category]float64, matches []StateMatch, an Analyzer) {
+	if len(matches) == 0 {
+		return
+	}
+	strategy, lexicon := an.Strategy, an.weights()
+	r.order, r.counts, r.totals, r.intensities = r.order[:0], r.counts[:0], r.totals[:0], r.intensities[:0]
+	for j, m := range matches {
+		i := 0
+		for i < len(r.order) && r.order[i] != m.Category {
+			i++
+		}
+		if i == len(r.order) {
+			r.order = append(r.order, m.Category)
+			r.counts = append(r.counts, 0)
+			r.totals = append(r.totals, 0)
+			r.intensities = append(r.intensities, 0)
+		}
+		w := 1.0
+		if lexicon != nil {
+			w = lexicon[m.State].weight()
+		}
+		r.counts[i]++
+		r.totals[i] += w
+		switch {
+		case an.SumWeights:
+			if !matchedState(matches[:j], m.State) {
+				r.intensities[i] += w
+			}
+		case w > r.intensities[i]:
+			r.intensities[i] = w
+		}
+	}
+	switch strategy {
+	case FirstMatch:
+		// the category of the first match is the first category
+		sums[r.order[0]] += r.intensities[0]
+	case Dominant:
+		best := 0
+		for i := 1; i < len(r.order); i++ {
+			if r.totals[i] > r.totals[best] || (r.totals[i] == r.totals[best] && r.counts[i] > r.counts[best]) {
+				best = i
+			}
+		}
+		sums[r.order[best]] += r.intensities[best]
+	case DropConflicting:
+		if conflictingDirections(matches) {
+			return
+		}
+		for i, c := range r.order {
+			sums[c] += r.intensities[i]
+		}
+	case Fractional:
+		share := 1 / float64(len(r.order))
+		for i, c := range r.order {
+			sums[c] += share * r.intensities[i]
+		}
+	default:
+		for i, c := range r.order {
+			sums[c] += r.intensities[i]
+		}
+	}
+}
+
+// matchedState returns true if one of the matches is of the state.
+func matchedState(matches []StateMatch, state string) bool {
+	for _, m := range matches {
+		if m.State == state {
+			return true
+		}
+	}
+	return false
+}
+
+// conflictingDirections returns true if the matches contain both positive and negative states.
+func conflictingDirections(matches []StateMatch) bool {
+	positive, negative := false, false
+	for _, m := range matches {
+		switch m.Direction {
+		case Positive:
+			positive = true
+		case Negative:
+			negative = true
+		}
+	}
+	return positive && negative
+}
+
+// CategoryWeights determines the sentiment categories of a text, resolved with the supplied strategy,
+// along with the weight with which the text counts in each of them.
+func CategoryWeights(textString string, strategy ConflictStrategy) map[Category]float64 {
+	return Analyzer{Strategy: strategy}.CategoryWeights(textString)
+}
+
+// CategoriesWithStrategy determines the sentiment categories of a text, resolved with the supplied strategy.
+func CategoriesWithStrategy(textString string, strategy ConflictStrategy) []Category {
+	return Analyzer{Strategy: strategy}.Categories(textString)
+}
+
+// AggregateCategories returns the aggregate sentiment value of every category found in the texts,
+// resolving multi-category texts with the supplied strategy. Each value ranges from 0 to 1.
+// The texts are expected to be already validated, see `ValidText`.
+func AggregateCategories(texts []string, strategy ConflictStrategy) (map[Category]float64, error) {
+	return Analyzer{Strategy: strategy}.AggregateCategories(texts)
+}
+
+// AggregateCategories returns the aggregate sentiment value of every category found in the texts,
+// resolving multi-category texts with the analyzer's strategy. Each value ranges from 0 to 1,
+// unless the analyzer sums the weights of the states, see `Analyzer.SumWeights`.
+// The texts are expected to be already validated, see `Analyzer.ValidText`.
+func (an Analyzer) AggregateCategories(texts []string) (map[Category]float64, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("texts is empty")
+	}
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := map[Category]float64{}
+	for _, t := range texts {
+		sc.AddCategoryWeights(res, t)
+	}
+	for c, s := range res {
+		res[c] = s / float64(len(texts))
+	}
+	return res, nil
+}
+
+// AggregateByAttribution returns, for every attribution, the aggregate sentiment value of every category
+// found in the texts, counting only the states attributed to it. Each value ranges from 0 to 1.
+func (an Analyzer) AggregateByAttribution(texts []string) (map[Attribution]map[Category]float64, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("texts is empty")
+	}
+	sc := getScanner(an)
+	defer putScanner(sc)
+	var r resolver
+	sums := map[Attribution]map[Category]float64{}
+	for _, t := range texts {
+		byAttribution := map[Attribution][]StateMatch{}
+		for _, m := range an.CountedStates(*sc.Analyze(t)) {
+			byAttribution[m.Attribution] = append(byAttribution[m.Attribution], m)
+		}
+		for a, matches := range byAttribution {
+			if sums[a] == nil {
+				sums[a] = map[Category]float64{}
+			}
+			r.addTo(sums[a], matches, an)
+		}
+	}
+	res := map[Attribution]map[Category]float64{}
+	for a, catgs := range sums {
+		res[a] = map[Category]float64{}
+		for c, s := range catgs {
+			res[a][c] = s / float64(len(texts))
+		}
+	}
+	return res, nil
+}
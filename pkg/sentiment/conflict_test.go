@@ -0,0 +1,103 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCategoryWeights(t *testing.T) {
+	type testCase struct {
+		textString string
+		strategy   ConflictStrategy
+		expected   map[Category]float64
+	}
+	cases := []testCase{
+		{textString: "I am xyz", strategy: CountAll, expected: map[Category]float64{}},
+		{textString: "I am happy", strategy: Fractional, expected: map[Category]float64{"joviality": 1}},
+		{textString: "I am sad, happy and joyful", strategy: CountAll, expected: map[Category]float64{"sadness": 1, "joviality": 1}},
+		{textString: "I am sad, happy and joyful", strategy: FirstMatch, expected: map[Category]float64{"sadness": 1}},
+		{textString: "I am sad, happy and joyful", strategy: Dominant, expected: map[Category]float64{"joviality": 1}},
+		{textString: "I am sad and happy", strategy: Dominant, expected: map[Category]float64{"sadness": 1}},
+		{textString: "I am sad, happy and joyful", strategy: DropConflicting, expected: map[Category]float64{}},
+		{textString: "I am sad and tired", strategy: DropConflicting, expected: map[Category]float64{"sadness": 1, "fatigue": 1}},
+		{textString: "I am sad, happy and tired", strategy: Fractional, expected: map[Category]float64{"sadness": 1.0 / 3, "joviality": 1.0 / 3, "fatigue": 1.0 / 3}}}
+
+	for _, c := range cases {
+		out := CategoryWeights(c.textString, c.strategy)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for k, v := range c.expected {
+			if out[k] != v {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+}
+
+func TestAggregateCategories(t *testing.T) {
+	texts := []string{"I am happy", "I am sad and happy", "I am tired", "I am calm"}
+	type testCase struct {
+		strategy ConflictStrategy
+		expected map[Category]float64
+	}
+	cases := []testCase{
+		{strategy: CountAll, expected: map[Category]float64{"joviality": 0.5, "sadness": 0.25, "fatigue": 0.25, "serenity": 0.25}},
+		{strategy: Fractional, expected: map[Category]float64{"joviality": 0.375, "sadness": 0.125, "fatigue": 0.25, "serenity": 0.25}},
+		{strategy: DropConflicting, expected: map[Category]float64{"joviality": 0.25, "fatigue": 0.25, "serenity": 0.25}}}
+
+	for _, c := range cases {
+		out, err := AggregateCategories(texts, c.strategy)
+		if err != nil {
+			t.Errorf("Failed: unexpected error %v", err)
+		}
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for k, v := range c.expected {
+			if out[k] != v {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+	if _, err := AggregateCategories([]string{}, CountAll); err == nil {
+		t.Errorf("Failed: expected an error for an empty collection of texts")
+	}
+}
+
+func TestParseConflictStrategy(t *testing.T) {
+	for _, s := range []ConflictStrategy{CountAll, FirstMatch, Dominant, DropConflicting, Fractional} {
+		out, err := ParseConflictStrategy(s.String())
+		if err != nil || out != s {
+			t.Errorf("Failed: expected %v, recieved %v (%v)", s, out, err)
+		}
+	}
+	if _, err := ParseConflictStrategy("majority"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown strategy")
+	}
+}
+
+var dominantLexicon = mustLexicon("dominant", []string{"furious", "jittery", "uneasy", "edgy"}, map[string]StateC{
+	"furious": {Category: Hostility, Direction: Negative, Weight: 1},
+	"jittery": {Category: Fear, Direction: Negative, Weight: 0.25},
+	"uneasy":  {Category: Fear, Direction: Negative, Weight: 0.25},
+	"edgy":    {Category: Fear, Direction: Negative, Weight: 0.75}}, LexiconOptions{})
+
+func TestWeightedDominant(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   map[Category]float64
+	}
+	testCases := []testCase{
+		{analyzer: Analyzer{Strategy: Dominant, Lexicon: dominantLexicon, Weighted: true}, textString: "I am furious, jittery and uneasy", expected: map[Category]float64{"hostility": 1}},
+		{analyzer: Analyzer{Strategy: Dominant, Lexicon: dominantLexicon}, textString: "I am furious, jittery and uneasy", expected: map[Category]float64{"fear": 1}},
+		{analyzer: Analyzer{Strategy: Dominant, Lexicon: dominantLexicon, Weighted: true}, textString: "I am furious, jittery and edgy", expected: map[Category]float64{"fear": 0.75}}}
+	for _, tc := range testCases {
+		if out := tc.analyzer.CategoryWeights(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
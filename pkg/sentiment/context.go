@@ -0,0 +1,187 @@
+package sentiment
+
+import (
+	"regexp"
+	"strings"
+)
+
+/*
+Word-sense disambiguation. Several PANAS-t states have everyday senses that are not sentiments:
+"blue" is a colour, "bold" a font, "strong" a coffee. A lexicon entry carries context rules, evaluated
+on the words around every match of the state during detection: a match is dropped if an exclusion rule
+applies, or if the entry has required-context rules and none of them applies. The rules of a lexicon
+are kept apart from its entries, by state, see `LexiconOptions.Contexts`.
+*/
+
+// ContextRule is a condition on the words around a state match.
+type ContextRule struct {
+	// Words are processed words; the rule applies if one of them appears within Window words of the match.
+	Words []string
+	// Phrases are processed words separated by single spaces, such as "out of the blue"; the rule applies if one
+	// of them appears in the context of the match, that is the words within Window words of the match, itself included.
+	Phrases []string
+	// Pattern is a regular expression; the rule applies if it matches the context of the match, with its words
+	// separated by single spaces. Unlike Words and Phrases, it builds the context text on every evaluation.
+	Pattern *regexp.Regexp
+	// Window is the number of words considered on each side of the match. It defaults to 2.
+	Window int
+}
+
+// ContextRules are the context rules of a lexicon entry.
+type ContextRules struct {
+	// Exclude drops a match if any of its rules applies.
+	Exclude []ContextRule
+	// Require drops a match if none of its rules applies. A match is kept if there are no required rules.
+	Require []ContextRule
+}
+
+const defaultContextWindow = 2
+
+// Allows returns true if a match of the entry covering words[position:position+length] is kept by the rules.
+func (rs ContextRules) Allows(words []string, position, length int) bool {
+	for _, r := range rs.Exclude {
+		if r.applies(words, position, length) {
+			return false
+		}
+	}
+	if len(rs.Require) == 0 {
+		return true
+	}
+	for _, r := range rs.Require {
+		if r.applies(words, position, length) {
+			return true
+		}
+	}
+	return false
+}
+
+// applies returns true if the rule applies to the match covering words[position:position+length].
+func (r ContextRule) applies(words []string, position, length int) bool {
+	window := r.Window
+	if window <= 0 {
+		window = defaultContextWindow
+	}
+	start, end := position-window, position+length+window
+	if start < 0 {
+		start = 0
+	}
+	if end > len(words) {
+		end = len(words)
+	}
+	for i := start; i < end; i++ {
+		if (i < position || i >= position+length) && containsString(r.Words, words[i]) {
+			return true
+		}
+		for _, p := range r.Phrases {
+			if phraseAt(words[:end], i, p) {
+				return true
+			}
+		}
+	}
+	if r.Pattern == nil {
+		return false
+	}
+	context := []string{}
+	for _, w := range words[start:end] {
+		if w != "" {
+			context = append(context, w)
+		}
+	}
+	return r.Pattern.MatchString(strings.Join(context, " "))
+}
+
+// phraseAt checks if the words starting at position i, skipping the empty ones, are the words of the phrase.
+func phraseAt(words []string, i int, phrase string) bool {
+	if i >= len(words) || words[i] == "" {
+		return false
+	}
+	for phrase != "" {
+		w := phrase
+		if j := strings.IndexByte(phrase, ' '); j >= 0 {
+			w, phrase = phrase[:j], phrase[j+1:]
+		} else {
+			phrase = ""
+		}
+		for i < len(words) && words[i] == "" {
+			i++
+		}
+		if i == len(words) || words[i] != w {
+			return false
+		}
+		i++
+	}
+	return true
+}
+
+func containsString(coll []string, s string) bool {
+	for _, c := range coll {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+Default context rules of the states of the built-in lexicons.
+*/
+
+// defaultContexts are the context rules of the built-in lexicons, by state.
+var defaultContexts = map[string]ContextRules{
+	"blue":   blueContext,
+	"bold":   boldContext,
+	"strong": strongContext,
+	"shaky":  shakyContext,
+	"alert":  alertContext,
+	"alone":  aloneContext,
+}
+
+var blueContext = ContextRules{Exclude: []ContextRule{{
+	Words: []string{
+		"sky", "skies", "shirt", "dress", "jeans", "jacket", "eyes", "hair", "car", "paint", "colour", "color",
+		"ocean", "sea", "water", "whale", "jay", "cheese", "moon", "tooth", "ribbon", "ink", "light", "lights",
+		"navy", "royal", "pale", "bright", "baby", "dark", "green", "red", "white", "yellow",
+	},
+}, {
+	Phrases: []string{"out of the blue", "once in a blue"},
+	Window:  3,
+}}}
+
+var boldContext = ContextRules{Exclude: []ContextRule{{
+	Words: []string{
+		"font", "fonts", "text", "type", "typeface", "print", "letters", "headline", "headlines", "italic", "italics",
+		"underline", "colour", "color", "colours", "colors", "flavour", "flavor", "design", "lipstick",
+	},
+	Phrases: []string{"in bold", "make it bold", "made it bold"},
+}}}
+
+var strongContext = ContextRules{Exclude: []ContextRule{{
+	Words: []string{
+		"coffee", "tea", "drink", "drinks", "beer", "wine", "smell", "taste", "flavour", "flavor", "wind", "winds",
+		"signal", "wifi", "connection", "password", "acid", "correlation", "evidence", "case", "economy",
+		"dollar", "currency", "sales", "demand", "earnings", "team", "defense", "defence",
+	},
+}}}
+
+var shakyContext = ContextRules{Exclude: []ContextRule{{
+	Words: []string{
+		"connection", "wifi", "internet", "signal", "network", "camera", "video", "footage", "cam", "table",
+		"ladder", "ground", "economy", "market", "start", "evidence", "foundation", "ceasefire", "truce", "recovery",
+	},
+}}}
+
+var alertContext = ContextRules{Exclude: []ContextRule{{
+	Words: []string{
+		"notification", "notifications", "push", "email", "emails", "app", "sms", "message", "alarm", "weather",
+		"amber", "security", "price", "news", "breaking", "spoiler", "trigger", "level", "code", "storm", "flood",
+		"tsunami", "tornado", "emergency", "red", "orange",
+	},
+	Phrases: []string{
+		"set alert", "sets alert", "setting alert", "get alert", "got alert", "receive alert", "received alert",
+		"an alert", "new alert",
+	},
+}}}
+
+var aloneContext = ContextRules{Exclude: []ContextRule{{
+	Phrases: []string{"let alone"},
+}}}
@@ -0,0 +1,90 @@
+package sentiment
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+func TestContextRulesAllows(t *testing.T) {
+	type testCase struct {
+		rules      ContextRules
+		textString string
+		position   int
+		length     int
+		expected   bool
+	}
+	exclude := ContextRules{Exclude: []ContextRule{{Words: []string{"coffee"}}}}
+	require := ContextRules{Require: []ContextRule{{Words: []string{"feel", "feeling"}, Window: 3}, {Pattern: regexp.MustCompile(`^i am`)}}}
+	cases := []testCase{
+		{rules: ContextRules{}, textString: "strong coffee", position: 0, length: 1, expected: true},
+		{rules: exclude, textString: "strong coffee", position: 0, length: 1, expected: false},
+		{rules: exclude, textString: "coffee, strong", position: 2, length: 1, expected: false},
+		{rules: exclude, textString: "strong and very hot coffee", position: 0, length: 1, expected: true},
+		{rules: exclude, textString: "I feel strong", position: 2, length: 1, expected: true},
+		{rules: require, textString: "I feel really very blue", position: 4, length: 1, expected: true},
+		{rules: require, textString: "I am blue", position: 2, length: 1, expected: true},
+		{rules: require, textString: "they are blue", position: 2, length: 1, expected: false},
+		{rules: ContextRules{Exclude: []ContextRule{{Pattern: regexp.MustCompile(`\bat self\b`)}}}, textString: "angry at self", position: 0, length: 3, expected: false},
+		{rules: ContextRules{Exclude: []ContextRule{{Phrases: []string{"out of the blue"}, Window: 3}}}, textString: "out of the... blue", position: 3, length: 1, expected: false},
+		{rules: ContextRules{Exclude: []ContextRule{{Phrases: []string{"out of the blue"}}}}, textString: "out of the blue", position: 3, length: 1, expected: true},
+		{rules: ContextRules{Exclude: []ContextRule{{Phrases: []string{"the blue"}}}}, textString: "the sky is blue", position: 3, length: 1, expected: true}}
+
+	for _, c := range cases {
+		out := c.rules.Allows(text.GenerateValidWords(c.textString), c.position, c.length)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+		}
+	}
+}
+
+func TestDefaultContextRules(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []string
+	}
+	cases := []testCase{
+		{textString: "I am feeling blue today", expected: []string{"blue"}},
+		{textString: "I am wearing my blue shirt", expected: []string{}},
+		{textString: "I got it out of the blue", expected: []string{}},
+		{textString: "I am bold and fearless", expected: []string{"bold", "fearless"}},
+		{textString: "I put the title in bold", expected: []string{}},
+		{textString: "I need a strong coffee", expected: []string{}},
+		{textString: "I am strong", expected: []string{"strong"}},
+		{textString: "my wifi is shaky again", expected: []string{}},
+		{textString: "I am alert", expected: []string{"alert"}},
+		{textString: "I got an alert on my phone", expected: []string{}},
+		{textString: "I can barely walk, let alone run", expected: []string{}},
+		{textString: "I feel alone", expected: []string{"alone"}}}
+
+	for _, c := range cases {
+		out := States(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v for %q", c.expected[i], out[i], c.textString)
+			}
+		}
+	}
+}
+
+func TestContextRulesAllocations(t *testing.T) {
+	var sc Scanner
+	texts := []string{"I feel blue", "I need a strong coffee", "I got an alert", "I can barely walk, let alone run"}
+	for _, s := range texts {
+		sc.ValidText(s)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, s := range texts {
+			sc.ValidText(s)
+		}
+	})
+	// at most one allocation per text, for its processed words
+	if allocs > float64(len(texts)) {
+		t.Errorf("Failed: expected at most %v allocations, recieved %v", len(texts), allocs)
+	}
+}
@@ -0,0 +1,238 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+Validity diagnostics. `ValidText` only tells whether a text is valid; `Analyzer.Diagnose` tells why it is not:
+the missing topic, self reference or state, along with the settings and the rules that dropped the matches
+that were found. `Analyzer.Rejections` counts the reasons over a corpus.
+*/
+
+// Reason is a set of reasons why a text is rejected, 0 if the text is valid.
+type Reason uint
+
+const (
+	// MissingTopic is a text without the topic.
+	MissingTopic Reason = 1 << iota
+	// MissingSelfRef is a text without a counted self reference.
+	MissingSelfRef
+	// MissingState is a text without a counted state.
+	MissingState
+	// ExcludedSpeech is a self reference or a state dropped for being in quoted, retweeted or reported speech.
+	ExcludedSpeech
+	// ExcludedModality is a state dropped for being in a question, a conditional, a modal clause or a wish.
+	ExcludedModality
+	// ExcludedTense is a state dropped for referring to the past or to the future.
+	ExcludedTense
+	// ExcludedAttribution is a state dropped for being attributed to another person.
+	ExcludedAttribution
+	// ExcludedWord is a state dropped for being one of the `Analyzer.Exclusions` of the state.
+	ExcludedWord
+	// LowConfidence is a self reference or a state dropped for its confidence, see `Analyzer.MinConfidence`.
+	LowConfidence
+	// ExcludedContext is a state dropped by its context rules, such as "out of the blue", see `ContextRules`.
+	ExcludedContext
+	// ExcludedNegation is a state dropped for being negated, such as "I am not happy", see `Analyzer.ExcludeNegated`.
+	ExcludedNegation
+)
+
+// reasons are the reasons, in the order they are reported.
+var reasons = []Reason{
+	MissingTopic, MissingSelfRef, MissingState,
+	ExcludedSpeech, ExcludedModality, ExcludedTense, ExcludedAttribution, ExcludedWord, LowConfidence, ExcludedContext,
+	ExcludedNegation,
+}
+
+var reasonNames = map[Reason]string{
+	MissingTopic:        "missing-topic",
+	MissingSelfRef:      "missing-self-ref",
+	MissingState:        "missing-state",
+	ExcludedSpeech:      "excluded-speech",
+	ExcludedModality:    "excluded-modality",
+	ExcludedTense:       "excluded-tense",
+	ExcludedAttribution: "excluded-attribution",
+	ExcludedWord:        "excluded-word",
+	LowConfidence:       "low-confidence",
+	ExcludedContext:     "excluded-context",
+	ExcludedNegation:    "excluded-negation",
+}
+
+// Has returns true if the set contains all the supplied reasons.
+func (r Reason) Has(reason Reason) bool {
+	return r&reason == reason
+}
+
+// Reasons returns the single reasons of the set.
+func (r Reason) Reasons() []Reason {
+	res := []Reason{}
+	for _, reason := range reasons {
+		if r.Has(reason) {
+			res = append(res, reason)
+		}
+	}
+	return res
+}
+
+// String returns the names of the reasons of the set, separated by '|', or "valid" for the empty set.
+func (r Reason) String() string {
+	if r == 0 {
+		return "valid"
+	}
+	names := []string{}
+	for _, reason := range r.Reasons() {
+		names = append(names, reasonNames[reason])
+		r &^= reason
+	}
+	if r != 0 {
+		names = append(names, fmt.Sprintf("Reason(%d)", uint(r)))
+	}
+	return strings.Join(names, "|")
+}
+
+// Diagnose returns the reasons why the text is not valid, see `Analyzer.Diagnose`.
+func Diagnose(textString string) Reason {
+	return Analyzer{}.Diagnose(textString)
+}
+
+// DiagnoseWithTopic returns the reasons why the text is not valid on the topic, see `Analyzer.DiagnoseWithTopic`.
+func DiagnoseWithTopic(textString, topic string) Reason {
+	return Analyzer{}.DiagnoseWithTopic(textString, topic)
+}
+
+// Diagnose returns the reasons why the text is not valid according to the analyzer, 0 if it is valid.
+// A text without a counted self reference or state is reported along with the reasons why the self references
+// and the states found in it were dropped.
+func (an Analyzer) Diagnose(textString string) Reason {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.Diagnose(textString)
+}
+
+// DiagnoseWithTopic returns the reasons why the text is not valid on the topic according to the analyzer,
+// 0 if it is valid. See `Analyzer.Diagnose`.
+func (an Analyzer) DiagnoseWithTopic(textString, topic string) Reason {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.DiagnoseWithTopic(textString, topic)
+}
+
+// Diagnose returns the reasons why the text is not valid according to the scanner's analyzer. See `Analyzer.Diagnose`.
+func (sc *Scanner) Diagnose(textString string) Reason {
+	return sc.Analyzer.diagnose(*sc.Analyze(textString))
+}
+
+// DiagnoseWithTopic returns the reasons why the text is not valid on the topic according to the scanner's analyzer.
+// See `Analyzer.DiagnoseWithTopic`.
+func (sc *Scanner) DiagnoseWithTopic(textString, topic string) Reason {
+	a := sc.analyzeWithTopic(textString, topic)
+	res := sc.Analyzer.diagnose(*a)
+	if !sc.hasTopic(a) {
+		res |= MissingTopic
+	}
+	return res
+}
+
+// diagnose returns the reasons why the analysis is not valid according to the analyzer.
+func (an Analyzer) diagnose(a Analysis) Reason {
+	var selfRefs, states Reason
+	selfRef := false
+	for _, m := range a.SelfRefs {
+		r := an.selfRefRejection(m)
+		selfRef = selfRef || r == 0
+		selfRefs |= r
+	}
+	state := false
+	for _, m := range a.States {
+		r := an.stateRejection(m)
+		state = state || r == 0
+		states |= r
+	}
+	var res Reason
+	if !selfRef {
+		res |= MissingSelfRef | selfRefs
+	}
+	if !state {
+		res |= MissingState | states
+		if droppedByContext(a) {
+			res |= ExcludedContext
+		}
+	}
+	return res
+}
+
+// droppedByContext returns true if a state hit of the analysis was dropped by the context rules of its state.
+func droppedByContext(a Analysis) bool {
+	for _, h := range a.Hits {
+		if h.Kind != StateHit {
+			continue
+		}
+		found := false
+		for _, m := range a.States {
+			if m.State == h.Pattern && m.Position == h.Position {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
+
+// RejectionStats are the validity statistics of a corpus of texts.
+type RejectionStats struct {
+	Texts int
+	Valid int
+	// Reasons is the number of rejected texts with each single reason. A text can have several reasons.
+	Reasons map[Reason]int
+}
+
+// Rejected returns the number of rejected texts.
+func (s RejectionStats) Rejected() int {
+	return s.Texts - s.Valid
+}
+
+// Fraction returns the fraction of the texts rejected with the supplied reason, 0 for an empty corpus.
+func (s RejectionStats) Fraction(reason Reason) float64 {
+	if s.Texts == 0 {
+		return 0
+	}
+	return float64(s.Reasons[reason]) / float64(s.Texts)
+}
+
+// Rejections returns the validity statistics of the texts according to the analyzer.
+func (an Analyzer) Rejections(texts []string) RejectionStats {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := RejectionStats{Reasons: map[Reason]int{}}
+	for _, t := range texts {
+		res.add(sc.Diagnose(t))
+	}
+	return res
+}
+
+// RejectionsWithTopic returns the validity statistics of the texts on the topic according to the analyzer.
+func (an Analyzer) RejectionsWithTopic(texts []string, topic string) RejectionStats {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := RejectionStats{Reasons: map[Reason]int{}}
+	for _, t := range texts {
+		res.add(sc.DiagnoseWithTopic(t, topic))
+	}
+	return res
+}
+
+func (s *RejectionStats) add(r Reason) {
+	s.Texts++
+	if r == 0 {
+		s.Valid++
+		return
+	}
+	for _, reason := range r.Reasons() {
+		s.Reasons[reason]++
+	}
+}
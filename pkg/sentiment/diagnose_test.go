@@ -0,0 +1,88 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   Reason
+	}
+	testCases := []testCase{
+		{analyzer: Analyzer{}, textString: "I am happy", expected: 0},
+		{analyzer: Analyzer{}, textString: "xyz", expected: MissingSelfRef | MissingState},
+		{analyzer: Analyzer{}, textString: "I am xyz", expected: MissingState},
+		{analyzer: Analyzer{}, textString: "very true RT @anna: I am sad", expected: MissingSelfRef | MissingState | ExcludedSpeech},
+		{analyzer: Analyzer{}, textString: "my mom said she is scared. I am here", expected: MissingState | ExcludedSpeech},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "am I happy?", expected: MissingState | ExcludedModality},
+		{analyzer: Analyzer{ExcludeNegated: true}, textString: "I am not happy", expected: MissingState | ExcludedNegation},
+		{analyzer: Analyzer{}, textString: "I am not happy", expected: 0},
+		{analyzer: Analyzer{PresentOnly: true}, textString: "I will be nervous tomorrow", expected: MissingState | ExcludedTense},
+		{analyzer: Analyzer{Attributions: []Attribution{FirstPlural}}, textString: "I am happy", expected: MissingState | ExcludedAttribution},
+		{analyzer: Analyzer{Exclusions: map[string][]string{"happy": {"happy"}}}, textString: "I am happy", expected: MissingState | ExcludedWord},
+		{analyzer: Analyzer{}, textString: "I got it out of the blue", expected: MissingState | ExcludedContext}}
+	for _, tc := range testCases {
+		out := tc.analyzer.Diagnose(tc.textString)
+		if out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+		if (out == 0) != tc.analyzer.ValidText(tc.textString) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", out == 0, tc.analyzer.ValidText(tc.textString), tc.textString)
+		}
+	}
+}
+
+func TestDiagnoseWithTopic(t *testing.T) {
+	type testCase struct {
+		textString string
+		topic      string
+		expected   Reason
+	}
+	testCases := []testCase{
+		{textString: "I am happy about covid", topic: "covid", expected: 0},
+		{textString: "I am happy", topic: "covid", expected: MissingTopic},
+		{textString: "covid xyz", topic: "covid", expected: MissingSelfRef | MissingState}}
+	for _, tc := range testCases {
+		out := DiagnoseWithTopic(tc.textString, tc.topic)
+		if out != tc.expected || (out == 0) != ValidTextWithTopic(tc.textString, tc.topic) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestReasonString(t *testing.T) {
+	type testCase struct {
+		reason   Reason
+		expected string
+	}
+	testCases := []testCase{
+		{reason: 0, expected: "valid"},
+		{reason: MissingState, expected: "missing-state"},
+		{reason: MissingSelfRef | ExcludedSpeech, expected: "missing-self-ref|excluded-speech"},
+		{reason: MissingTopic | 1<<20, expected: "missing-topic|Reason(1048576)"}}
+	for _, tc := range testCases {
+		if out := tc.reason.String(); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v", tc.expected, out)
+		}
+	}
+}
+
+func TestRejections(t *testing.T) {
+	texts := []string{"I am happy about covid", "I am happy", "I am xyz", "xyz"}
+	out := Analyzer{}.RejectionsWithTopic(texts, "covid")
+	expected := RejectionStats{Texts: 4, Valid: 1,
+		Reasons: map[Reason]int{MissingTopic: 3, MissingState: 2, MissingSelfRef: 1}}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+	if out.Rejected() != 3 || out.Fraction(MissingTopic) != 0.75 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", []float64{3, 0.75}, out.Rejected(), out.Fraction(MissingTopic))
+	}
+	expected = RejectionStats{Texts: 4, Valid: 2, Reasons: map[Reason]int{MissingState: 2, MissingSelfRef: 1}}
+	if out := (Analyzer{}).Rejections(texts); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+}
@@ -30,7 +30,7 @@ func BuildSelfRefSoundexIndex() map[string][]string {
 
 // BuildStatesSoundexIndex generates a map of Soundex codes with their corresponding original state strings.
 func BuildStatesSoundexIndex() map[string][]string {
-	return BuildSoundexIndex(SelfReferences)
+	return BuildSoundexIndex(StatesColl)
 }
 
 /*
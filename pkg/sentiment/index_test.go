@@ -2,6 +2,8 @@ package sentiment
 
 import (
 	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
 )
 
 func TestBuildSoundexIndex(t *testing.T) {
@@ -12,3 +14,29 @@ func TestBuildSoundexIndex(t *testing.T) {
 		t.Errorf("Failed: expected %v, recieved %v", expected, out)
 	}
 }
+
+func TestBuildStatesSoundexIndex(t *testing.T) {
+	out := BuildStatesSoundexIndex()
+	if len(out) != len(StatesSoundexIndex) {
+		t.Errorf("Failed: expected %v, recieved %v", StatesSoundexIndex, out)
+	}
+	for code, states := range StatesSoundexIndex {
+		if len(out[code]) != len(states) {
+			t.Errorf("Failed: expected %v, recieved %v", states, out[code])
+		}
+	}
+}
+
+func TestBuildStatesSoundexIndexStates(t *testing.T) {
+	out := BuildStatesSoundexIndex()
+	for _, s := range StatesColl {
+		if !containsString(out[text.Soundex(s)], s) {
+			t.Errorf("Failed: expected %v, recieved %v", s, out[text.Soundex(s)])
+		}
+	}
+	for _, r := range SelfReferences {
+		if containsString(out[text.Soundex(r)], r) {
+			t.Errorf("Failed: expected %v, recieved %v", nil, out[text.Soundex(r)])
+		}
+	}
+}
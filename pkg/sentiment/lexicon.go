@@ -0,0 +1,280 @@
+package sentiment
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+/*
+Named lexicons. The default lexicon is the PANAS-t subset of `StatesColl` and `StatesCategories`;
+the full 60-item PANAS-X ("The PANAS-X: Manual for the Positive and Negative Affect Schedule - Expanded Form",
+Watson & Clark, 1994) and the 10-item international short form I-PANAS-SF (Thompson, 2007) are shipped
+alongside it, and can be selected with `Analyzer.Lexicon`.
+*/
+
+// PANASXItems are the 60 items of the PANAS-X, grouped by scale. The items of the general dimension scales
+// that belong to no basic emotion scale (active, attentive, inspired, interested, upset, distressed) come last.
+var PANASXItems = []string{
+	// joviality
+	"happy", "joyful", "delighted", "cheerful", "excited", "enthusiastic", "lively", "energetic",
+	// self-assurance
+	"proud", "strong", "confident", "bold", "daring", "fearless",
+	// attentiveness
+	"alert", "attentive", "concentrating", "determined",
+	// fear
+	"afraid", "scared", "frightened", "nervous", "jittery", "shaky",
+	// hostility
+	"angry", "hostile", "irritable", "scornful", "disgusted", "loathing",
+	// guilt
+	"guilty", "ashamed", "blameworthy", "angry at self", "disgusted with self", "dissatisfied with self",
+	// sadness
+	"sad", "blue", "downhearted", "alone", "lonely",
+	// shyness
+	"shy", "bashful", "sheepish", "timid",
+	// fatigue
+	"sleepy", "tired", "sluggish", "drowsy",
+	// serenity
+	"calm", "relaxed", "at ease",
+	// surprise
+	"amazed", "surprised", "astonished",
+	// general dimension scales only
+	"active", "inspired", "interested", "upset", "distressed",
+}
+
+// PANASXCategories maps the PANAS-X items to their basic emotion scale, with the categories of `StatesCategories`,
+// and to their direction. The items that only belong to the general dimension scales are in the "general" category.
+// Note that the PANAS-X has no "fatigued" item: its fatigue scale is made of sleepy, tired, sluggish and drowsy.
+var PANASXCategories = func() map[string]StateC {
+	res := map[string]StateC{}
+	for s, sc := range StatesCategories {
+		res[s] = sc
+	}
+	// the PANAS-t "attentiveness" state is the "attentive" item of the PANAS-X
+	delete(res, "attentiveness")
+	res["attentive"] = StateC{Category: Attentiveness, Direction: Positive}
+	for _, s := range []string{"active", "inspired", "interested", "upset", "distressed"} {
+		res[s] = GeneralPosNegStates[s]
+	}
+	return res
+}()
+
+// IPANASSFItems are the 10 items of the I-PANAS-SF, the five positive ones first.
+var IPANASSFItems = []string{
+	"alert", "inspired", "determined", "attentive", "active",
+	"upset", "hostile", "ashamed", "nervous", "afraid",
+}
+
+// IPANASSFCategories maps the I-PANAS-SF items to the "general" category and to their direction.
+var IPANASSFCategories = func() map[string]StateC {
+	res := map[string]StateC{}
+	for _, s := range IPANASSFItems {
+		res[s] = GeneralPosNegStates[s]
+	}
+	return res
+}()
+
+// Lexicon is a named collection of sentiment states, with their categories and directions.
+// Create it with `NewLexicon`.
+type Lexicon struct {
+	Name string
+	// States are the states, in the order their matches are reported for a word.
+	States []string
+	// Categories maps every state to its category and direction.
+	Categories map[string]StateC
+	matcher    *Matcher
+	// index is the Soundex index of the states, see `Lexicon.SoundexIndex`.
+	index map[string][]string
+	// contexts are the context rules of the states, see `LexiconOptions.Contexts`.
+	contexts map[string]ContextRules
+	// categories are the custom categories of the lexicon, with their direction, see `LexiconOptions.Categories`.
+	categories map[Category]Direction
+}
+
+// LexiconOptions are the optional parts of a lexicon.
+type LexiconOptions struct {
+	// Contexts are the context rules of the states, by state, see `ContextRules`.
+	Contexts map[string]ContextRules
+	// Categories are the custom categories of the lexicon, such as "frustration" or "burnout", with the direction
+	// they roll up under. They are only known to the lexicon, and must differ from the built-in ones.
+	Categories map[Category]Direction
+}
+
+// NewLexicon compiles a lexicon with no options, see `NewLexiconWith`.
+func NewLexicon(name string, states []string, categories map[string]StateC) (*Lexicon, error) {
+	return NewLexiconWith(name, states, categories, LexiconOptions{})
+}
+
+// NewLexiconWith compiles a lexicon. Every state must have a category, its category and direction must be known,
+// see `KnownCategories`, `LexiconOptions.Categories` and `KnownDirections`, and its direction must be the one
+// of its category, if any.
+// Its weight and dimensions, if any, must be in range, see `StateC.Weight` and `Dimensions`.
+// The context rules must be the ones of states of the lexicon.
+func NewLexiconWith(name string, states []string, categories map[string]StateC, options LexiconOptions) (*Lexicon, error) {
+	for c, d := range options.Categories {
+		switch {
+		case c == "":
+			return nil, fmt.Errorf("empty category")
+		case c.Valid():
+			return nil, fmt.Errorf("category %q is a built-in category", string(c))
+		case categoryAliases[string(c)] != "":
+			return nil, fmt.Errorf("category %q is an alias of %q", string(c), string(categoryAliases[string(c)]))
+		case !d.Valid():
+			return nil, fmt.Errorf("category %q: unknown direction %q", string(c), string(d))
+		}
+	}
+	l := &Lexicon{Name: name, States: states, Categories: categories, categories: options.Categories}
+	for _, s := range states {
+		sc, ok := categories[s]
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("state %q has no category", s)
+		case !l.ValidCategory(sc.Category):
+			return nil, fmt.Errorf("state %q: unknown category %q", s, string(sc.Category))
+		case !sc.Direction.Valid():
+			return nil, fmt.Errorf("state %q: unknown direction %q", s, string(sc.Direction))
+		}
+		if d, ok := l.CategoryDirection(sc.Category); ok && d != sc.Direction {
+			return nil, fmt.Errorf("state %q: direction %q differs from the direction %q of category %q",
+				s, string(sc.Direction), string(d), string(sc.Category))
+		}
+		if err := sc.checkWeights(); err != nil {
+			return nil, fmt.Errorf("state %q: %v", s, err)
+		}
+	}
+	for s := range options.Contexts {
+		if !containsString(states, s) {
+			return nil, fmt.Errorf("context rules of unknown state %q", s)
+		}
+	}
+	l.matcher, l.index, l.contexts = newMatcher(states, nil), BuildSoundexIndex(states), options.Contexts
+	return l, nil
+}
+
+func mustLexicon(name string, states []string, categories map[string]StateC, options LexiconOptions) *Lexicon {
+	l, err := NewLexiconWith(name, states, categories, options)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// The built-in lexicons.
+var (
+	// PANASt is the lexicon of the PANAS-t paper, made of `StatesColl` and `StatesCategories`. It is the default lexicon.
+	PANASt = &Lexicon{
+		Name: "panas-t", States: StatesColl, Categories: StatesCategories,
+		matcher: defaultMatcher, index: StatesSoundexIndex, contexts: defaultContexts,
+	}
+	// PANASX is the lexicon of the 60 PANAS-X items.
+	PANASX = mustLexicon("panas-x", PANASXItems, PANASXCategories, LexiconOptions{Contexts: builtinContexts(PANASXItems)})
+	// IPANASSF is the lexicon of the 10 I-PANAS-SF items.
+	IPANASSF = mustLexicon("i-panas-sf", IPANASSFItems, IPANASSFCategories, LexiconOptions{Contexts: builtinContexts(IPANASSFItems)})
+)
+
+// builtinContexts returns the default context rules of the states.
+func builtinContexts(states []string) map[string]ContextRules {
+	res := map[string]ContextRules{}
+	for _, s := range states {
+		if rs, ok := defaultContexts[s]; ok {
+			res[s] = rs
+		}
+	}
+	return res
+}
+
+// Lexicons are the built-in lexicons, by name.
+var Lexicons = map[string]*Lexicon{
+	PANASt.Name:   PANASt,
+	PANASX.Name:   PANASX,
+	IPANASSF.Name: IPANASSF,
+}
+
+// LexiconByName returns the lexicon registered in `Lexicons` with the supplied name.
+func LexiconByName(name string) (*Lexicon, error) {
+	if l, ok := Lexicons[name]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unknown lexicon %q", name)
+}
+
+// LexiconNames returns the sorted names of the built-in lexicons.
+func LexiconNames() []string {
+	res := []string{}
+	for name := range Lexicons {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// ValidCategory returns true if the category is one of `KnownCategories` or a custom category of the lexicon.
+func (l *Lexicon) ValidCategory(c Category) bool {
+	_, ok := l.categories[c]
+	return ok || c.Valid()
+}
+
+// CategoryDirection returns the direction under which the category rolls up, see `Category.Direction`,
+// including the custom categories of the lexicon.
+func (l *Lexicon) CategoryDirection(c Category) (Direction, bool) {
+	if d, ok := l.categories[c]; ok {
+		return d, true
+	}
+	return c.Direction()
+}
+
+// ParseCategory returns the category with the supplied name, see `ParseCategory`, including the custom categories of the lexicon.
+func (l *Lexicon) ParseCategory(name string) (Category, error) {
+	if _, ok := l.categories[Category(name)]; ok {
+		return Category(name), nil
+	}
+	return ParseCategory(name)
+}
+
+// RegisterCategories registers the custom categories of the lexicon for the text and JSON encodings of `Category`,
+// such as the keys of `Rollup.Categories`, until unregister is called. The categories stay registered
+// as long as one of the lexicons that registered them is.
+func (l *Lexicon) RegisterCategories() (unregister func()) {
+	registeredCategories.Lock()
+	defer registeredCategories.Unlock()
+	for c := range l.categories {
+		registeredCategories.counts[c]++
+	}
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			registeredCategories.Lock()
+			defer registeredCategories.Unlock()
+			for c := range l.categories {
+				if registeredCategories.counts[c]--; registeredCategories.counts[c] == 0 {
+					delete(registeredCategories.counts, c)
+				}
+			}
+		})
+	}
+}
+
+// AllCategories returns the built-in categories followed by the sorted custom categories of the lexicon.
+func (l *Lexicon) AllCategories() []Category {
+	custom := []Category{}
+	for c := range l.categories {
+		custom = append(custom, c)
+	}
+	sort.Slice(custom, func(i, j int) bool { return custom[i] < custom[j] })
+	return append(append([]Category{}, KnownCategories...), custom...)
+}
+
+// SoundexIndex returns the map of the Soundex codes of the states of the lexicon to the states,
+// like `StatesSoundexIndex` for `PANASt`. It must not be modified.
+func (l *Lexicon) SoundexIndex() map[string][]string {
+	return l.index
+}
+
+// ReadExclusions reads the exclusions written by `WriteExclusions`, for the states of the lexicon.
+func (l *Lexicon) ReadExclusions(r io.Reader) (map[string][]string, error) {
+	return readExclusions(r, func(state string) bool {
+		_, ok := l.Categories[state]
+		return ok
+	})
+}
@@ -0,0 +1,160 @@
+package sentiment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+func TestBuiltinLexicons(t *testing.T) {
+	type testCase struct {
+		lexicon  *Lexicon
+		items    int
+		positive int
+		negative int
+	}
+	cases := []testCase{
+		{lexicon: PANASt, items: 55, positive: 18, negative: 23},
+		{lexicon: PANASX, items: 60, positive: 21, negative: 25},
+		{lexicon: IPANASSF, items: 10, positive: 5, negative: 5}}
+
+	for _, c := range cases {
+		if len(c.lexicon.States) != c.items || len(c.lexicon.Categories) != c.items {
+			t.Errorf("Failed: expected %v items, recieved %v and %v in %v", c.items, len(c.lexicon.States), len(c.lexicon.Categories), c.lexicon.Name)
+		}
+		positive, negative := 0, 0
+		for _, s := range c.lexicon.States {
+			switch c.lexicon.Categories[s].Direction {
+			case "positive":
+				positive++
+			case "negative":
+				negative++
+			}
+		}
+		if positive != c.positive || negative != c.negative {
+			t.Errorf("Failed: expected %v and %v, recieved %v and %v in %v", c.positive, c.negative, positive, negative, c.lexicon.Name)
+		}
+	}
+	if PANASXCategories["attentive"].Category != "attentiveness" || PANASXCategories["upset"].Category != "general" {
+		t.Errorf("Failed: recieved %v and %v", PANASXCategories["attentive"], PANASXCategories["upset"])
+	}
+}
+
+func TestLexiconByName(t *testing.T) {
+	for _, name := range LexiconNames() {
+		l, err := LexiconByName(name)
+		if err != nil || l.Name != name {
+			t.Errorf("Failed: expected %v, recieved %v, %v", name, l, err)
+		}
+	}
+	if _, err := LexiconByName("panas-y"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown lexicon")
+	}
+}
+
+func TestNewLexicon(t *testing.T) {
+	if _, err := NewLexicon("broken", []string{"happy", "glad"}, StatesCategories); err == nil {
+		t.Errorf("Failed: expected an error for a state with no category")
+	}
+	for _, sc := range []StateC{{Category: "jovility", Direction: Positive}, {Category: Joviality, Direction: "postive"}} {
+		if _, err := NewLexicon("typo", []string{"glad"}, map[string]StateC{"glad": sc}); err == nil {
+			t.Errorf("Failed: expected an error for %v", sc)
+		}
+	}
+	l, err := NewLexicon("glad", []string{"glad"}, map[string]StateC{"glad": {Category: "joviality", Direction: "positive"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	an := Analyzer{Lexicon: l}
+	if !an.ValidText("I am glad") || an.ValidText("I am happy") {
+		t.Errorf("Failed: expected the custom lexicon to be used")
+	}
+}
+
+func TestStateCComparable(t *testing.T) {
+	if expected := (StateC{Category: Sadness, Direction: Negative}); StatesCategories["blue"] != expected {
+		t.Errorf("Failed: expected %v, recieved %v", expected, StatesCategories["blue"])
+	}
+}
+
+func TestNewLexiconWith(t *testing.T) {
+	categories := map[string]StateC{"glad": {Category: Joviality, Direction: Positive}}
+	contexts := map[string]ContextRules{"glad": {Exclude: []ContextRule{{Phrases: []string{"glad rags"}}}}}
+	if _, err := NewLexiconWith("glad", []string{"glad"}, categories, LexiconOptions{Contexts: map[string]ContextRules{"happy": {}}}); err == nil {
+		t.Errorf("Failed: expected an error for the context rules of an unknown state")
+	}
+	l, err := NewLexiconWith("glad", []string{"glad"}, categories, LexiconOptions{Contexts: contexts})
+	if err != nil {
+		t.Fatal(err)
+	}
+	an := Analyzer{Lexicon: l}
+	if !an.ValidText("I am glad") || an.ValidText("I am in my glad rags") {
+		t.Errorf("Failed: expected the context rules of the custom lexicon to be used")
+	}
+}
+
+func TestAnalyzerLexicon(t *testing.T) {
+	type testCase struct {
+		lexicon    *Lexicon
+		textString string
+		expected   []string
+	}
+	cases := []testCase{
+		{lexicon: nil, textString: "I am upset and inspired", expected: []string{}},
+		{lexicon: PANASX, textString: "I am upset and inspired", expected: []string{"upset", "inspired"}},
+		{lexicon: PANASX, textString: "I am attentive", expected: []string{"attentive"}},
+		{lexicon: IPANASSF, textString: "I am happy but nervous", expected: []string{"nervous"}}}
+
+	for _, c := range cases {
+		out := Analyzer{Lexicon: c.lexicon}.States(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected[i], out[i])
+			}
+		}
+	}
+	an := Analyzer{Lexicon: PANASX}
+	if !an.ValidTextWithTopic("I am upset about covid", "covid") || (Analyzer{}).ValidTextWithTopic("I am upset about covid", "covid") {
+		t.Errorf("Failed: expected the topic validation to use the lexicon")
+	}
+	out, err := an.AggregateCategories([]string{"I am upset", "I am happy"})
+	if err != nil || out["general"] != 0.5 || out["joviality"] != 0.5 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", map[Category]float64{"general": 0.5, "joviality": 0.5}, out, err)
+	}
+}
+
+func TestLexiconReadExclusions(t *testing.T) {
+	l := mustLexicon("glad", []string{"glad"}, map[string]StateC{"glad": {Category: Joviality, Direction: Positive}}, LexiconOptions{})
+	out, err := l.ReadExclusions(strings.NewReader(`{"glad": ["glade"]}`))
+	if err != nil || len(out["glad"]) != 1 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", map[string][]string{"glad": {"glade"}}, out, err)
+	}
+	if _, err := l.ReadExclusions(strings.NewReader(`{"happy": ["hippo"]}`)); err == nil {
+		t.Errorf("Failed: expected an error for a state of another lexicon")
+	}
+	if _, err := ReadExclusions(strings.NewReader(`{"upset": ["upside"]}`)); err != nil {
+		t.Errorf("Failed: expected the states of the built-in lexicons, recieved %v", err)
+	}
+}
+
+func TestAnalyzerMatchStates(t *testing.T) {
+	words := text.GenerateValidWords("I am upset")
+	if out := MatchStates(words); len(out) != 0 {
+		t.Errorf("Failed: expected no match, recieved %v", out)
+	}
+	an := Analyzer{Lexicon: PANASX}
+	if out := an.MatchStates(words); len(out) != 1 || out[0].State != "upset" {
+		t.Errorf("Failed: expected %v, recieved %v", "upset", out)
+	}
+	if ContainsValidSentiment(words) || !an.ContainsValidSentiment(words) {
+		t.Errorf("Failed: expected the states of the analyzer's lexicon to be used")
+	}
+	if len(PANASt.SoundexIndex()) != len(StatesSoundexIndex) {
+		t.Errorf("Failed: expected %v, recieved %v", StatesSoundexIndex, PANASt.SoundexIndex())
+	}
+}
@@ -0,0 +1,112 @@
+package sentiment
+
+import (
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Ordered state matches of a text. Unlike `States`, which only reports the set of detected states,
+the matches keep the position of every hit, which is what the conflict resolution strategies need.
+*/
+
+// StateMatch is a single occurrence of a sentiment state in a text.
+type StateMatch struct {
+	// State is the matched state, as listed in the lexicon, `StatesCategories` by default.
+	State string
+	// Word is the processed word of the text that matched the state.
+	Word string
+	// Position is the index of the word in the output of `text.GenerateValidWords`.
+	Position int
+	// Length is the number of words of the state, such as 2 for "at ease".
+	Length    int
+	Category  Category
+	Direction Direction
+	// Speech is the kind of speech the word belongs to. It is always `Direct` for the matches of `MatchStates`.
+	Speech Speech
+	// Modality is the modality of the clause the word belongs to. It is always `Assertive` for the matches of `MatchStates`.
+	Modality Modality
+	// Tense is the temporal reference of the clause the word belongs to. It is always `Present` for the matches of `MatchStates`.
+	Tense Tense
+	// Attribution is the person the state is attributed to. It is always `FirstSingular` for the matches of `MatchStates`.
+	Attribution Attribution
+	// Negated is true if a negation, such as "not" or "never", precedes the word in its clause.
+	// It is always false for the matches of `MatchStates`.
+	Negated bool
+	// Kind is how the word relates to the state.
+	Kind MatchKind
+	// Confidence is the confidence of the match, from 0 to 1, based on its kind, on the context rules
+	// of the state and on the modality of its clause. See `MatchKind`.
+	Confidence float64
+}
+
+// SelfRefMatch is a single occurrence of a self reference in a text.
+type SelfRefMatch struct {
+	// SelfRef is the matched self reference, as listed in `SelfReferences`.
+	SelfRef string
+	// Word is the processed word of the text that matched the self reference.
+	Word string
+	// Position is the index of the word in the output of `text.GenerateValidWords`.
+	Position int
+	// Speech is the kind of speech the word belongs to. It is always `Direct` for the matches of `MatchSelfRefs`.
+	Speech Speech
+	// Kind is how the word relates to the self reference.
+	Kind MatchKind
+	// Confidence is the confidence of the match, from 0 to 1, based on its kind.
+	Confidence float64
+}
+
+// MatchStates returns the state matches of a words-collection, in the order they appear.
+// A word whose Soundex code is shared by more than one state produces one match per state.
+// The matches that the context rules of their state exclude are dropped, see `ContextRules`.
+// The states are the ones of the default lexicon, see `Analyzer.MatchStates` for the other lexicons.
+func MatchStates(words []string) []StateMatch {
+	return Analyzer{}.MatchStates(words)
+}
+
+// MatchStates returns the state matches of a words-collection with the states of the analyzer's lexicon,
+// in the order they appear. See `MatchStates`.
+func (an Analyzer) MatchStates(words []string) []StateMatch {
+	lex := an.lexicon()
+	return appendStateMatches([]StateMatch{}, lex.matcher, lex.matcher.Hits(words), words, lex)
+}
+
+// appendStateMatches appends to dst the state matches among the hits of the matcher that are allowed by the
+// context rules of their state in the lexicon. Their confidence is the one of an assertive clause.
+func appendStateMatches(dst []StateMatch, m *Matcher, hits []Hit, words []string, lex *Lexicon) []StateMatch {
+	for _, h := range hits {
+		if h.Kind == StateHit {
+			rules := lex.contexts[h.Pattern]
+			if !rules.Allows(words, h.Position, h.Length) {
+				continue
+			}
+			sc := lex.Categories[h.Pattern]
+			kind, confidence := m.classify(h)
+			dst = append(dst, StateMatch{
+				State: h.Pattern, Word: h.Word, Position: h.Position, Length: h.Length, Category: sc.Category, Direction: sc.Direction,
+				Kind: kind, Confidence: confidence * rules.ambiguity(words, h.Position, h.Length),
+			})
+		}
+	}
+	return dst
+}
+
+// MatchSelfRefs returns the self-reference matches of a words-collection, in the order they appear.
+func MatchSelfRefs(words []string) []SelfRefMatch {
+	return appendSelfRefMatches([]SelfRefMatch{}, defaultMatcher, defaultMatcher.Hits(words))
+}
+
+// appendSelfRefMatches appends the self-reference matches among the hits of the matcher to dst.
+func appendSelfRefMatches(dst []SelfRefMatch, m *Matcher, hits []Hit) []SelfRefMatch {
+	for _, h := range hits {
+		if h.Kind == SelfRefHit {
+			kind, confidence := m.classify(h)
+			dst = append(dst, SelfRefMatch{SelfRef: h.Pattern, Word: h.Word, Position: h.Position, Kind: kind, Confidence: confidence})
+		}
+	}
+	return dst
+}
+
+// StateMatches returns the state matches of a text, in the order they appear.
+func StateMatches(textString string) []StateMatch {
+	return MatchStates(text.GenerateValidWords(textString))
+}
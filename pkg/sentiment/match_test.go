@@ -0,0 +1,36 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestStateMatches(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []StateMatch
+	}
+	cases := []testCase{
+		{textString: "I am xyz", expected: []StateMatch{}},
+		{textString: "I am happy", expected: []StateMatch{
+			{State: "happy", Word: "happy", Position: 2, Length: 1, Category: "joviality", Direction: "positive", Kind: ExactMatch, Confidence: 1}}},
+		{textString: "sad but happy", expected: []StateMatch{
+			{State: "sad", Word: "sad", Position: 0, Length: 1, Category: "sadness", Direction: "negative", Kind: ExactMatch, Confidence: 1},
+			{State: "happy", Word: "happy", Position: 2, Length: 1, Category: "joviality", Direction: "positive", Kind: ExactMatch, Confidence: 1}}},
+		{textString: "very angry", expected: []StateMatch{
+			{State: "angry", Word: "angry", Position: 1, Length: 1, Category: "hostility", Direction: "negative", Kind: ExactMatch, Confidence: 1}}},
+		{textString: "so happy", expected: []StateMatch{
+			{State: "happy", Word: "happy", Position: 1, Length: 1, Category: "joviality", Direction: "positive", Kind: ExactMatch, Confidence: 1}}}}
+
+	for _, c := range cases {
+		out := StateMatches(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected[i], out[i])
+			}
+		}
+	}
+}
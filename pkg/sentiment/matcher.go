@@ -0,0 +1,236 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Compiled single-pass matcher. A `Matcher` holds every pattern of interest (self references, states,
+modifiers and topics) in a word trie, keyed by the exact processed words, and in a map keyed by the
+normalized (Soundex) code, so that a text is traversed, and each of its words encoded, only once.
+*/
+
+// Modifiers are the intensifiers, diminishers and negations that can modify a sentiment state.
+// They are only matched exactly, since their Soundex codes collide with too many other words.
+var Modifiers = []string{
+	"very", "really", "so", "too", "extremely", "totally", "super", "quite", "truly", "incredibly",
+	"slightly", "somewhat", "a bit", "a little", "kind of", "sort of",
+	"not", "no", "never", "dont", "didnt", "doesnt", "isnt", "arent", "wasnt", "werent", "cant", "wont", "aint",
+}
+
+// HitKind is the kind of pattern a word of a text matched.
+type HitKind int
+
+const (
+	// SelfRefHit is a match of one of the `SelfReferences`.
+	SelfRefHit HitKind = iota
+	// StateHit is a match of one of the states of the lexicon, `StatesCategories` by default.
+	StateHit
+	// ModifierHit is a match of one of the `Modifiers`.
+	ModifierHit
+	// TopicHit is a match of one of the topics the matcher was compiled with.
+	TopicHit
+)
+
+var hitKindNames = map[HitKind]string{
+	SelfRefHit:  "self-ref",
+	StateHit:    "state",
+	ModifierHit: "modifier",
+	TopicHit:    "topic",
+}
+
+// String returns the name of the hit kind.
+func (k HitKind) String() string {
+	if name, ok := hitKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("HitKind(%d)", int(k))
+}
+
+// Hit is a single occurrence of a pattern in a text.
+type Hit struct {
+	Kind HitKind
+	// Pattern is the matched pattern, as listed in the base data or as supplied to `NewMatcher`.
+	Pattern string
+	// Word is the processed word of the text where the match starts.
+	Word string
+	// Position is the index of Word in the output of `text.GenerateValidWords`.
+	Position int
+	// Length is the number of words covered by an exact match of a multi-word pattern, and 1 otherwise.
+	Length int
+	// Exact is true if the processed words of the pattern appear as such, and false for a normalized (Soundex) match.
+	Exact bool
+}
+
+// pattern is a compiled pattern; phonetic single-word patterns are also matched by their normalized code.
+type pattern struct {
+	kind     HitKind
+	value    string
+	phonetic bool
+}
+
+// trieNode is a node of the trie of the exact processed words of the patterns.
+type trieNode struct {
+	children map[string]*trieNode
+	// patterns are the indexes of the patterns that end at this node.
+	patterns []int
+}
+
+// Matcher finds the self references, states, modifiers and topics of a words-collection in a single traversal.
+// A Matcher is immutable once compiled, and safe for concurrent use.
+type Matcher struct {
+	patterns []pattern
+	root     trieNode
+	// codes maps the normalized code of the phonetic patterns to their indexes.
+	codes map[string][]int
+	// keys maps the patterns to their processed words, concatenated, to classify their hits, see `MatchKind`.
+	keys map[string]string
+}
+
+// NewMatcher compiles a matcher for the self references, the states and the modifiers of the base data,
+// along with the supplied topics. Self references, states and topics are matched exactly or by Soundex code,
+// modifiers are only matched exactly.
+func NewMatcher(topics ...string) *Matcher {
+	return newMatcher(StatesColl, topics)
+}
+
+// newMatcher compiles a matcher for the self references and the modifiers of the base data, along with
+// the supplied states and topics.
+func newMatcher(states, topics []string) *Matcher {
+	m := &Matcher{codes: map[string][]int{}, keys: map[string]string{}}
+	for _, r := range SelfReferences {
+		m.add(pattern{kind: SelfRefHit, value: r, phonetic: true})
+	}
+	for _, s := range states {
+		m.add(pattern{kind: StateHit, value: s, phonetic: true})
+	}
+	for _, mod := range Modifiers {
+		m.add(pattern{kind: ModifierHit, value: mod})
+	}
+	for _, t := range topics {
+		m.add(pattern{kind: TopicHit, value: t, phonetic: true})
+	}
+	return m
+}
+
+func (m *Matcher) add(p pattern) {
+	i := len(m.patterns)
+	m.patterns = append(m.patterns, p)
+	node, words, key := &m.root, 0, ""
+	for _, f := range strings.Fields(p.value) {
+		w := string(text.AppendWord(nil, f))
+		if w == "" {
+			continue
+		}
+		key += w
+		if node.children == nil {
+			node.children = map[string]*trieNode{}
+		}
+		if node.children[w] == nil {
+			node.children[w] = &trieNode{}
+		}
+		node = node.children[w]
+		words++
+	}
+	if words > 0 {
+		node.patterns = append(node.patterns, i)
+	}
+	m.keys[p.value] = key
+	// the code of a phrase is the one of its first word: multi-word patterns are only matched word by word
+	if p.phonetic && words == 1 {
+		if code := text.Soundex(key); code != "" {
+			m.codes[code] = append(m.codes[code], i)
+		}
+	}
+}
+
+// Hits returns the hits of a words-collection, in the order they appear.
+func (m *Matcher) Hits(words []string) []Hit {
+	return m.AppendHits([]Hit{}, words)
+}
+
+// found is a pattern found at a word, with the number of words covered by an exact match (0 if normalized).
+type found struct {
+	pattern int
+	length  int
+}
+
+// AppendHits appends the hits of a words-collection to dst, in the order they appear, and returns the extended slice.
+// The hits of a word are ordered as the patterns were compiled, and a pattern that matches a word both exactly
+// and by normalized code is reported once, as an exact hit.
+func (m *Matcher) AppendHits(dst []Hit, words []string) []Hit {
+	var buf [4]byte
+	var arr [8]found
+	for i, w := range words {
+		if w == "" {
+			continue
+		}
+		fs := arr[:0]
+		node := &m.root
+		for j := i; j < len(words); j++ {
+			if node = node.children[words[j]]; node == nil {
+				break
+			}
+			for _, p := range node.patterns {
+				fs = append(fs, found{pattern: p, length: j - i + 1})
+			}
+		}
+		// a modifier, such as "so", is not also matched by normalized code, such as "shy"
+		if !m.isModifier(fs) {
+			for _, p := range m.codes[string(text.AppendSoundex(buf[:0], w))] {
+				if !hasPattern(fs, p) {
+					fs = append(fs, found{pattern: p})
+				}
+			}
+		}
+		// a word has only a few hits, hence an insertion sort
+		for k := 1; k < len(fs); k++ {
+			for l := k; l > 0 && fs[l].pattern < fs[l-1].pattern; l-- {
+				fs[l], fs[l-1] = fs[l-1], fs[l]
+			}
+		}
+		for _, f := range fs {
+			p := m.patterns[f.pattern]
+			h := Hit{Kind: p.kind, Pattern: p.value, Word: w, Position: i, Length: f.length, Exact: f.length > 0}
+			if !h.Exact {
+				h.Length = 1
+			}
+			dst = append(dst, h)
+		}
+	}
+	return dst
+}
+
+// isModifier checks if the word of the found patterns is itself one of the `Modifiers`.
+func (m *Matcher) isModifier(fs []found) bool {
+	for _, f := range fs {
+		if f.length == 1 && m.patterns[f.pattern].kind == ModifierHit {
+			return true
+		}
+	}
+	return false
+}
+
+func hasPattern(fs []found, p int) bool {
+	for _, f := range fs {
+		if f.pattern == p {
+			return true
+		}
+	}
+	return false
+}
+
+func containsKind(hits []Hit, kind HitKind) bool {
+	for _, h := range hits {
+		if h.Kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultMatcher is the matcher of the base data, without topics.
+var defaultMatcher = NewMatcher()
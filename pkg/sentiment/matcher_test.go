@@ -0,0 +1,87 @@
+package sentiment
+
+import (
+	"testing"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+func TestMatcherHits(t *testing.T) {
+	type testCase struct {
+		topics     []string
+		textString string
+		expected   []Hit
+	}
+	cases := []testCase{
+		{textString: "", expected: []Hit{}},
+		{textString: "xyz", expected: []Hit{}},
+		{textString: "very hapy", expected: []Hit{
+			{Kind: ModifierHit, Pattern: "very", Word: "very", Position: 0, Length: 1, Exact: true},
+			{Kind: StateHit, Pattern: "happy", Word: "hapy", Position: 1, Length: 1}}},
+		{textString: "Angry at self!", expected: []Hit{
+			{Kind: StateHit, Pattern: "angry", Word: "angry", Position: 0, Length: 1, Exact: true},
+			{Kind: StateHit, Pattern: "angry at self", Word: "angry", Position: 0, Length: 3, Exact: true},
+			{Kind: StateHit, Pattern: "sleepy", Word: "self", Position: 2, Length: 1}}},
+		{textString: "I am so happy", expected: []Hit{
+			{Kind: SelfRefHit, Pattern: "I am", Word: "i", Position: 0, Length: 2, Exact: true},
+			{Kind: SelfRefHit, Pattern: "I", Word: "i", Position: 0, Length: 1, Exact: true},
+			{Kind: SelfRefHit, Pattern: "am", Word: "am", Position: 1, Length: 1, Exact: true},
+			{Kind: ModifierHit, Pattern: "so", Word: "so", Position: 2, Length: 1, Exact: true},
+			{Kind: StateHit, Pattern: "happy", Word: "happy", Position: 3, Length: 1, Exact: true}}},
+		{textString: "a bit tired", expected: []Hit{
+			{Kind: ModifierHit, Pattern: "a bit", Word: "a", Position: 0, Length: 2, Exact: true},
+			{Kind: StateHit, Pattern: "tired", Word: "tired", Position: 2, Length: 1, Exact: true}}},
+		{topics: []string{"covid"}, textString: "I'm not scared of Covid", expected: []Hit{
+			{Kind: SelfRefHit, Pattern: "I'm", Word: "im", Position: 0, Length: 1, Exact: true},
+			{Kind: ModifierHit, Pattern: "not", Word: "not", Position: 1, Length: 1, Exact: true},
+			{Kind: StateHit, Pattern: "scared", Word: "scared", Position: 2, Length: 1, Exact: true},
+			{Kind: TopicHit, Pattern: "covid", Word: "covid", Position: 4, Length: 1, Exact: true}}},
+		{topics: []string{"covid", ""}, textString: "covd", expected: []Hit{
+			{Kind: TopicHit, Pattern: "covid", Word: "covd", Position: 0, Length: 1}}}}
+
+	for _, c := range cases {
+		out := NewMatcher(c.topics...).Hits(text.GenerateValidWords(c.textString))
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected[i], out[i])
+			}
+		}
+	}
+}
+
+func TestMatcherAgreesWithIndexes(t *testing.T) {
+	texts := []string{"I am happy", "feeling blue and lonely", "me, myself and my shyness", "sad but happy", "nothing here"}
+	for _, s := range texts {
+		words := text.GenerateValidWords(s)
+		hits := defaultMatcher.Hits(words)
+		if containsKind(hits, SelfRefHit) != ContainsOneSelfRef(words) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", ContainsOneSelfRef(words), containsKind(hits, SelfRefHit), s)
+		}
+		if containsKind(hits, StateHit) != ContainsValidSentiment(words) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", ContainsValidSentiment(words), containsKind(hits, StateHit), s)
+		}
+		if containsKind(NewMatcher("covid").Hits(words), TopicHit) != ContainsTopic("covid", words) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", ContainsTopic("covid", words), !ContainsTopic("covid", words), s)
+		}
+	}
+}
+
+func TestHitKindString(t *testing.T) {
+	if SelfRefHit.String() != "self-ref" || TopicHit.String() != "topic" || HitKind(9).String() != "HitKind(9)" {
+		t.Errorf("Failed: recieved %v, %v, %v", SelfRefHit, TopicHit, HitKind(9))
+	}
+}
+
+func BenchmarkMatcherHits(b *testing.B) {
+	words := text.GenerateValidWords("I am so happy that covid is getting under control, but a bit tired")
+	m := NewMatcher("covid")
+	hits := []Hit{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		hits = m.AppendHits(hits[:0], words)
+	}
+}
@@ -0,0 +1,75 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Detection of the modality of the words: questions ("Am I happy?"), conditionals ("if I were confident"),
+modal verbs ("I would be angry") and wishes ("I wish I was calm") do not assert the author's current state.
+The markers are looked up in the clause of the word, and the question mark in its sentence.
+*/
+
+// Modality is the modality of the clause a word belongs to.
+type Modality int
+
+const (
+	// Assertive is a plain statement.
+	Assertive Modality = iota
+	// Interrogative is a question, such as "am I happy?".
+	Interrogative
+	// Conditional is a condition, such as "if I were confident".
+	Conditional
+	// Modal is a statement qualified by a modal verb, such as "I would be angry".
+	Modal
+	// Wish is a wish or a hope, such as "I wish I was calm".
+	Wish
+)
+
+var modalityNames = map[Modality]string{
+	Assertive:     "assertive",
+	Interrogative: "interrogative",
+	Conditional:   "conditional",
+	Modal:         "modal",
+	Wish:          "wish",
+}
+
+// String returns the name of the modality.
+func (m Modality) String() string {
+	if name, ok := modalityNames[m]; ok {
+		return name
+	}
+	return fmt.Sprintf("Modality(%d)", int(m))
+}
+
+// conditionalMarkers introduce a conditional clause.
+var conditionalMarkers = map[string]bool{
+	"if": true, "unless": true, "suppose": true, "supposing": true, "imagine": true, "assuming": true, "whether": true,
+}
+
+// modalMarkers are the modal verbs that qualify a clause.
+var modalMarkers = map[string]bool{
+	"would": true, "could": true, "might": true, "should": true, "may": true, "id": true,
+	"wouldnt": true, "couldnt": true, "shouldnt": true, "wouldve": true, "couldve": true, "mightve": true, "shouldve": true,
+}
+
+// wishMarkers introduce a wish or a hope.
+var wishMarkers = map[string]bool{
+	"wish": true, "wished": true, "wishing": true, "hope": true, "hoped": true, "hoping": true,
+	"want": true, "wanted": true, "wanna": true, "longing": true,
+}
+
+// questionOpeners are the auxiliary verbs that open a question when followed by a pronoun ("am I", "are you").
+var questionOpeners = map[string]bool{
+	"am": true, "are": true, "is": true, "was": true, "were": true, "do": true, "does": true, "did": true,
+	"can": true, "could": true, "will": true, "would": true, "should": true, "have": true, "has": true,
+}
+
+// pronouns are the subject pronouns.
+var pronouns = map[string]bool{"i": true, "you": true, "he": true, "she": true, "we": true, "they": true, "it": true}
+
+// DetectModality returns the modality of every token of a text.
+// The result is aligned with the output of `text.GenerateValidWords`.
+func DetectModality(textString string) []Modality {
+	return annotateText(textString).Modality
+}
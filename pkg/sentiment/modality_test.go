@@ -0,0 +1,55 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestDetectModality(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []Modality
+	}
+	cases := []testCase{
+		{textString: "I am happy", expected: []Modality{Assertive, Assertive, Assertive}},
+		{textString: "am I happy? I am calm", expected: []Modality{Interrogative, Interrogative, Interrogative, Assertive, Assertive, Assertive}},
+		{textString: "am I happy", expected: []Modality{Interrogative, Interrogative, Interrogative}},
+		{textString: "if I were confident, I am calm", expected: []Modality{Conditional, Conditional, Conditional, Conditional, Assertive, Assertive, Assertive}},
+		{textString: "I would be angry if", expected: []Modality{Assertive, Modal, Modal, Modal, Conditional}},
+		{textString: "I wish I was calm. I am sad", expected: []Modality{Assertive, Wish, Wish, Wish, Wish, Assertive, Assertive, Assertive}}}
+
+	for _, c := range cases {
+		out := DetectModality(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+				break
+			}
+		}
+	}
+}
+
+func TestAnalyzerExcludeNonAssertive(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   bool
+	}
+	cases := []testCase{
+		{analyzer: Analyzer{}, textString: "am I happy?", expected: true},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "am I happy?", expected: false},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "I would be angry if they left", expected: false},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "if it rains I am calm", expected: false},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "I wish I was calm", expected: false},
+		{analyzer: Analyzer{ExcludeNonAssertive: true}, textString: "if it rains, I am calm", expected: true}}
+
+	for _, c := range cases {
+		out := c.analyzer.ValidText(c.textString)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+		}
+	}
+}
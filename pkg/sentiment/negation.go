@@ -0,0 +1,15 @@
+package sentiment
+
+/*
+Detection of the negated states: "I am not happy" and "I never feel calm" deny the state rather than express it.
+A state is negated by a negation found shortly before it, in its clause.
+*/
+
+// negations are the `Modifiers` that negate the state that follows them.
+var negations = map[string]bool{
+	"not": true, "no": true, "never": true, "dont": true, "didnt": true, "doesnt": true, "isnt": true,
+	"arent": true, "wasnt": true, "werent": true, "cant": true, "wont": true, "aint": true,
+}
+
+// maxNegationGap is the number of words allowed between a negation and the state it negates.
+const maxNegationGap = 2
@@ -0,0 +1,48 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNegatedStates(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []bool
+	}
+	testCases := []testCase{
+		{textString: "I am happy", expected: []bool{false}},
+		{textString: "I am not happy", expected: []bool{true}},
+		{textString: "I don't feel happy", expected: []bool{true}},
+		{textString: "I am never really calm", expected: []bool{true}},
+		{textString: "I am not tired but happy", expected: []bool{true, false}},
+		{textString: "no, I am happy", expected: []bool{false}},
+		{textString: "not now, not ever. I am happy", expected: []bool{false}}}
+	for _, tc := range testCases {
+		out := []bool{}
+		for _, m := range Analyze(tc.textString).States {
+			out = append(out, m.Negated)
+		}
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestAnalyzerExcludeNegated(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   bool
+	}
+	testCases := []testCase{
+		{analyzer: Analyzer{}, textString: "I am not happy", expected: true},
+		{analyzer: Analyzer{ExcludeNegated: true}, textString: "I am not happy", expected: false},
+		{analyzer: Analyzer{ExcludeNegated: true}, textString: "I am not tired but happy", expected: true},
+		{analyzer: Analyzer{ExcludeNegated: true}, textString: "I am happy", expected: true}}
+	for _, tc := range testCases {
+		if out := tc.analyzer.ValidText(tc.textString); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
@@ -16,10 +16,15 @@ Based on the PANAS-t paper.
 
 // ContainsTopic checks if the words-collection contains at least one word
 // that is similar to the supplied word (topic).
+// A topic with no letters or digits is never contained.
 func ContainsTopic(topic string, words []string) bool {
-	topicSoundex := text.Soundex(topic)
+	var topicBuf, wordBuf [4]byte
+	topicSoundex := text.AppendSoundex(topicBuf[:0], topic)
+	if len(topicSoundex) == 0 {
+		return false
+	}
 	for _, w := range words {
-		if topicSoundex == text.Soundex(w) {
+		if string(topicSoundex) == string(text.AppendSoundex(wordBuf[:0], w)) {
 			return true
 		}
 	}
@@ -28,8 +33,9 @@ func ContainsTopic(topic string, words []string) bool {
 
 // InIndex checks if the words-collection contains at least one word that exists in the supplied index map.
 func InIndex(indexMap map[string][]string, words []string) bool {
+	var buf [4]byte
 	for _, w := range words {
-		if indexMap[text.Soundex(w)] != nil {
+		if indexMap[string(text.AppendSoundex(buf[:0], w))] != nil {
 			return true
 		}
 	}
@@ -43,74 +49,42 @@ func ContainsOneSelfRef(words []string) bool {
 }
 
 // ContainsValidSentiment checks if the words-collection contains at least one word that is similar to
-// one of the sentimentStates recognized by the PANAS-t paper.
+// one of the sentimentStates recognized by the PANAS-t paper. See `Analyzer.ContainsValidSentiment` for the other lexicons.
 func ContainsValidSentiment(words []string) bool {
-	return InIndex(StatesSoundexIndex, words)
+	return Analyzer{}.ContainsValidSentiment(words)
+}
+
+// ContainsValidSentiment checks if the words-collection contains at least one word that is similar to
+// one of the states of the analyzer's lexicon, see `Lexicon.SoundexIndex`.
+func (an Analyzer) ContainsValidSentiment(words []string) bool {
+	return InIndex(an.lexicon().SoundexIndex(), words)
 }
 
 // ValidText returns true if it finds the text to be valid to be considered for sentiment analysis.
-// Means, the text must contain a self reference and a sentiment state.
+// Means, the text must contain a self reference and a sentiment state, outside of quoted, retweeted
+// and reported speech.
 func ValidText(textString string) bool {
-	words := text.GenerateValidWords(textString)
-	if ContainsOneSelfRef(words) && ContainsValidSentiment(words) {
-		return true
-	}
-	return false
+	return Analyzer{}.ValidText(textString)
 }
 
 // ValidTextWithTopic returns true if it finds the text to be valid to be considered for sentiment analysis on a topic.
-// Means, the text must contain the target topic, a self reference, and a sentiment state.
+// Means, the text must contain the target topic, a self reference, and a sentiment state, with the self
+// reference and the state outside of quoted, retweeted and reported speech.
+// The topic can be a topic query, such as "corona OR covid", see `ParseTopicQuery`.
 func ValidTextWithTopic(textString, topic string) bool {
-	words := text.GenerateValidWords(textString)
-	if ContainsTopic(topic, words) && ContainsOneSelfRef(words) && ContainsValidSentiment(words) {
-		return true
-	}
-	return false
+	return Analyzer{}.ValidTextWithTopic(textString, topic)
 }
 
-// States detrmines the sentiment states of a text.
+// States detrmines the sentiment states of a text, outside of quoted, retweeted and reported speech.
 func States(textString string) []string {
-	states := map[string]bool{}
-	res := []string{}
-	words := text.GenerateValidWords(textString)
-	for _, w := range words {
-		sts := StatesSoundexIndex[text.Soundex(w)]
-		if len(sts) == 1 {
-			states[sts[0]] = true
-
-		} else if len(sts) > 1 {
-			for _, s := range sts {
-				states[s] = true
-			}
-		}
-	}
-	for k, _ := range states {
-		res = append(res, k)
-	}
-	return res
+	return Analyzer{}.States(textString)
 }
 
-// Categories detrmines the sentiment categories of a text.
-func Categories(textString string) []string {
-	// Currently, the conflict resolution is ignored when a tweet contains more than one sentiment.
-	// For now, we will simply consider such tweets a part of all identified sentiment categories.
-	catgs := map[string]bool{}
-	res := []string{}
-	words := text.GenerateValidWords(textString)
-	for _, w := range words {
-		states := StatesSoundexIndex[text.Soundex(w)]
-		if states != nil {
-			for _, s := range states {
-				// NOTE: the states contains 1 or 2 items, therefore, this nested for-loop is perfectly alright.
-				stateCatg := StatesCategories[s].Category
-				catgs[stateCatg] = true
-			}
-		}
-	}
-	for k, _ := range catgs {
-		res = append(res, k)
-	}
-	return res
+// Categories detrmines the sentiment categories of a text, outside of quoted, retweeted and reported speech.
+// A text that contains more than one sentiment is considered a part of all identified sentiment categories,
+// see `CategoriesWithStrategy` for the other conflict resolution strategies.
+func Categories(textString string) []Category {
+	return CategoriesWithStrategy(textString, CountAll)
 }
 
 // CategoryAggregate returns the aggregate sentiment value of a category. It ranges from 0 to 1.
@@ -118,5 +92,11 @@ func CategoryAggregate(categoryTextsCount int, totalTextsCount int) (float64, er
 	if totalTextsCount == 0 {
 		return 0, fmt.Errorf("totalTextsCount is 0")
 	}
+	if categoryTextsCount < 0 || totalTextsCount < 0 {
+		return 0, fmt.Errorf("negative texts count")
+	}
+	if categoryTextsCount > totalTextsCount {
+		return 0, fmt.Errorf("categoryTextsCount %d is greater than totalTextsCount %d", categoryTextsCount, totalTextsCount)
+	}
 	return float64(categoryTextsCount) / float64(totalTextsCount), nil
 }
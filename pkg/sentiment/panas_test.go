@@ -12,7 +12,8 @@ func TestContainsTopic(t *testing.T) {
 	}
 	cases := []testCase{
 		{topic: "Covid", words: []string{"this", "is", "covid", "time"}, expected: true},
-		{topic: "Corona", words: []string{"this", "is", "covid", "time"}, expected: false}}
+		{topic: "Corona", words: []string{"this", "is", "covid", "time"}, expected: false},
+		{topic: "", words: []string{"this", "is", "", "time"}, expected: false}}
 	for _, c := range cases {
 		out := ContainsTopic(c.topic, c.words)
 		if out != c.expected {
@@ -123,6 +124,8 @@ func TestStates(t *testing.T) {
 	cases := []testCase{
 		{textString: "I am xyz", expected: []string{}},
 		{textString: "I am happy", expected: []string{"happy"}},
+		{textString: "I am - happy", expected: []string{"happy"}},
+		{textString: "", expected: []string{}},
 		{textString: "I am both happy and sad", expectedMap: map[string]bool{"happy": true, "sad": true}}}
 
 	for _, c := range cases {
@@ -151,13 +154,14 @@ func TestStates(t *testing.T) {
 func TestCategories(t *testing.T) {
 	type testCase struct {
 		textString  string
-		expected    []string
-		expectedMap map[string]bool
+		expected    []Category
+		expectedMap map[Category]bool
 	}
 	cases := []testCase{
-		{textString: "I am xyz", expected: []string{}},
-		{textString: "I am happy", expected: []string{"jovility"}},
-		{textString: "I am happy, joyful, and sad", expectedMap: map[string]bool{"jovility": true, "sadness": true}}}
+		{textString: "I am xyz", expected: []Category{}},
+		{textString: "I am happy", expected: []Category{"joviality"}},
+		{textString: "I am angry", expected: []Category{"hostility"}},
+		{textString: "I am happy, joyful, and sad", expectedMap: map[Category]bool{"joviality": true, "sadness": true}}}
 
 	for _, c := range cases {
 		out := Categories(c.textString)
@@ -198,4 +202,9 @@ func TestCategoryAggregate(t *testing.T) {
 			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
 		}
 	}
+	for _, counts := range [][2]int{{1, 0}, {-1, 10}, {11, 10}} {
+		if _, err := CategoryAggregate(counts[0], counts[1]); err == nil {
+			t.Errorf("Failed: expected an error for %v", counts)
+		}
+	}
 }
@@ -0,0 +1,82 @@
+package sentiment
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Phonetic encoders used to match the words of a text with the self references, states and topics.
+The built-in indexes (`SelfRefSoundexIndex` and `StatesSoundexIndex`) are keyed by `text.Soundex` codes;
+the functions below build and query indexes with any of the encoders.
+*/
+
+// PhoneticEncoder encodes a word into its phonetic code, so that words that sound alike share a code.
+// Encoders that can return several alternative codes for a word also implement `EncodeAll(string) []string`.
+type PhoneticEncoder = text.PhoneticEncoder
+
+// Encoders are the available phonetic encoders, by name.
+var Encoders = map[string]PhoneticEncoder{
+	"soundex":          text.LegacySoundex{},
+	"american-soundex": text.AmericanSoundex{},
+	"refined-soundex":  text.RefinedSoundex{},
+	"metaphone":        text.Metaphone{},
+	"double-metaphone": text.DoubleMetaphone{},
+	"nysiis":           text.NYSIIS{},
+	"cologne":          text.Cologne{},
+	"daitch-mokotoff":  text.DaitchMokotoff{},
+}
+
+// DefaultEncoder is the encoder of the built-in indexes.
+var DefaultEncoder PhoneticEncoder = text.LegacySoundex{}
+
+// EncoderByName returns the encoder registered in `Encoders` with the supplied name.
+func EncoderByName(name string) (PhoneticEncoder, error) {
+	if e, ok := Encoders[name]; ok {
+		return e, nil
+	}
+	return nil, fmt.Errorf("unknown phonetic encoder %q", name)
+}
+
+// EncoderNames returns the sorted names of the available encoders.
+func EncoderNames() []string {
+	res := []string{}
+	for name := range Encoders {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// BuildIndex generates a map of phonetic codes, computed by the encoder, with their corresponding original strings.
+// A string with several alternative codes is listed under each of them.
+func BuildIndex(encoder PhoneticEncoder, words []string) map[string][]string {
+	res := map[string][]string{}
+	for _, w := range words {
+		for _, code := range text.Codes(encoder, w) {
+			res[code] = append(res[code], w)
+		}
+	}
+	return res
+}
+
+// InIndexWith checks if the words-collection contains at least one word that exists in the supplied index map,
+// built with the same encoder.
+func InIndexWith(encoder PhoneticEncoder, indexMap map[string][]string, words []string) bool {
+	for _, w := range words {
+		for _, code := range text.Codes(encoder, w) {
+			if indexMap[code] != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ContainsTopicWith checks if the words-collection contains at least one word that sounds like the supplied
+// word (topic), according to the encoder.
+func ContainsTopicWith(encoder PhoneticEncoder, topic string, words []string) bool {
+	return InIndexWith(encoder, BuildIndex(encoder, []string{topic}), words)
+}
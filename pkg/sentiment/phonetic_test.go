@@ -0,0 +1,57 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestBuildIndex(t *testing.T) {
+	out := BuildIndex(Encoders["double-metaphone"], []string{"Smith", "Schmidt", "happy"})
+	expected := map[string][]string{"SM0": {"Smith"}, "XMT": {"Smith", "Schmidt"}, "SMT": {"Schmidt"}, "HP": {"happy"}}
+	if len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v", expected, out)
+	}
+	for code, words := range expected {
+		if len(out[code]) != len(words) {
+			t.Errorf("Failed: expected %v, recieved %v", expected, out)
+			continue
+		}
+		for i := range words {
+			if out[code][i] != words[i] {
+				t.Errorf("Failed: expected %v, recieved %v", expected, out)
+			}
+		}
+	}
+}
+
+func TestContainsTopicWith(t *testing.T) {
+	type testCase struct {
+		encoder  string
+		topic    string
+		words    []string
+		expected bool
+	}
+	cases := []testCase{
+		{encoder: "soundex", topic: "Covid", words: []string{"this", "is", "covid", "time"}, expected: true},
+		{encoder: "metaphone", topic: "Covid", words: []string{"this", "is", "kovid", "time"}, expected: true},
+		{encoder: "double-metaphone", topic: "Schmidt", words: []string{"mr", "smith"}, expected: true},
+		{encoder: "cologne", topic: "Meyer", words: []string{"herr", "maier"}, expected: true},
+		{encoder: "nysiis", topic: "Corona", words: []string{"this", "is", "covid", "time"}, expected: false}}
+
+	for _, c := range cases {
+		out := ContainsTopicWith(Encoders[c.encoder], c.topic, c.words)
+		if out != c.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %v", c.expected, out, c.encoder)
+		}
+	}
+}
+
+func TestEncoderByName(t *testing.T) {
+	for _, name := range EncoderNames() {
+		if _, err := EncoderByName(name); err != nil {
+			t.Errorf("Failed: unexpected error %v", err)
+		}
+	}
+	if _, err := EncoderByName("caverphone"); err == nil {
+		t.Errorf("Failed: expected an error for an unknown encoder")
+	}
+}
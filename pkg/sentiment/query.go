@@ -0,0 +1,608 @@
+package sentiment
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Topic query language. A topic query is a boolean expression of terms, matched against the words of a text:
+
+	covid                 the word, or a word with the same Soundex code ("covd")
+	=covid                the word only, case-insensitively
+	"climate change"      the consecutive words, exactly; ~"climate change" matches them by Soundex code
+	covid*  c?vid         the words matching the wildcards, exactly
+	#covid                the hashtag; #covid* matches the hashtags matching the wildcards
+	corona OR covid       either term; AND, or no operator, requires both; NOT excludes a term
+	vaccine NEAR/3 covid  both terms, with at most 3 words between them; NEAR alone allows 5 words
+
+NOT binds tighter than AND, which binds tighter than OR; parentheses group the expressions. The operands of
+NEAR must be terms. The words of the terms are processed like the words of the texts: "covid-19" is "covid19".
+*/
+
+// defaultNearDistance is the number of words allowed between the operands of NEAR without a distance.
+const defaultNearDistance = 5
+
+// TopicQuery is a compiled topic query. Create it with `ParseTopicQuery`.
+type TopicQuery struct {
+	source string
+	root   queryNode
+}
+
+// ParseTopicQuery compiles a topic query.
+func ParseTopicQuery(query string) (*TopicQuery, error) {
+	tokens, err := lexQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic query %q: %v", query, err)
+	}
+	p := queryParser{tokens: tokens}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("invalid topic query %q: empty query", query)
+	}
+	root, err := p.parseOr()
+	if err == nil && p.pos < len(tokens) {
+		err = fmt.Errorf("unexpected %q", tokens[p.pos].text)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid topic query %q: %v", query, err)
+	}
+	return &TopicQuery{source: query, root: root}, nil
+}
+
+// MustParseTopicQuery compiles a topic query, and panics if it is invalid.
+func MustParseTopicQuery(query string) *TopicQuery {
+	q, err := ParseTopicQuery(query)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// String returns the source of the query.
+func (q *TopicQuery) String() string {
+	return q.source
+}
+
+// Match returns true if the text matches the query.
+func (q *TopicQuery) Match(textString string) bool {
+	tokens := text.Tokenize(textString)
+	c := queryContext{words: make([]string, len(tokens)), tokens: tokens}
+	for i, tk := range tokens {
+		c.words[i] = tk.Word
+	}
+	return q.root.matches(&c)
+}
+
+// matchAnalysis returns true if the analyzed text matches the query.
+func (q *TopicQuery) matchAnalysis(a Analysis) bool {
+	return q.matches(&queryContext{words: a.Words, text: a.Text})
+}
+
+// matches returns true if the text matches the query.
+func (q *TopicQuery) matches(c *queryContext) bool {
+	return q.root.matches(c)
+}
+
+// appendSpans appends to dst the positions and lengths of the words of the text that match the terms
+// of the query, if the text matches the query.
+func (q *TopicQuery) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	if !q.matches(c) {
+		return dst
+	}
+	return q.root.appendSpans(dst, c)
+}
+
+// plainWord returns true if the query is a single word with no modifier, matched like the topics of `Matcher`.
+func (q *TopicQuery) plainWord() bool {
+	t, ok := q.root.(*termNode)
+	return ok && t.phonetic && !t.modified && !t.hashtag && !t.wildcard && len(t.words) == 1
+}
+
+// compileTopic returns the query of a topic, or nil if the topic is a plain word, matched by the topic
+// patterns of the matchers. An invalid query is matched as a plain word if it has no query syntax, such as
+// a word with no letters or digits, and is never matched otherwise, see `CheckTopic`.
+func compileTopic(topic string) *TopicQuery {
+	q, err := ParseTopicQuery(topic)
+	switch {
+	case err != nil && !hasQuerySyntax(topic):
+		return nil
+	case err != nil:
+		return &TopicQuery{source: topic, root: orNode{}}
+	case q.plainWord():
+		return nil
+	}
+	return q
+}
+
+// CheckTopic returns the error of a topic that is an invalid topic query, such as "(corona OR covid",
+// which is never matched. A topic with no query syntax is matched as a plain word, and is always valid.
+func CheckTopic(topic string) error {
+	if _, err := ParseTopicQuery(topic); err != nil && hasQuerySyntax(topic) {
+		return err
+	}
+	return nil
+}
+
+// hasQuerySyntax returns true if the topic has parentheses, phrases, operators, modifiers, wildcards or hashtags.
+func hasQuerySyntax(topic string) bool {
+	tokens, err := lexQuery(topic)
+	if err != nil {
+		return true
+	}
+	for _, tk := range tokens {
+		switch {
+		case tk.kind != wordToken || tk.modifier != 0 || strings.ContainsAny(tk.text, "*?#"):
+			return true
+		case tk.text == "OR" || tk.text == "AND" || tk.text == "NOT" || tk.text == "NEAR" || strings.HasPrefix(tk.text, "NEAR/"):
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTopic returns the rule satisfied by the analyses of the texts that match the query.
+func MatchesTopic(q *TopicQuery) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		return q.matchAnalysis(a)
+	}
+}
+
+// ValidTextsWithTopic returns the texts that are valid to be considered for sentiment analysis on a topic,
+// see `Analyzer.ValidTextsWithTopic`.
+func ValidTextsWithTopic(texts []string, topic string) []string {
+	return Analyzer{}.ValidTextsWithTopic(texts, topic)
+}
+
+// ValidTextsWithTopic returns the texts that are valid to be considered for sentiment analysis on a topic,
+// which can be a topic query, see `ParseTopicQuery`. The topic is compiled once for all the texts.
+func (an Analyzer) ValidTextsWithTopic(texts []string, topic string) []string {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := []string{}
+	for _, t := range texts {
+		if sc.ValidTextWithTopic(t, topic) {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// queryContext is a text matched by the queries.
+type queryContext struct {
+	words []string
+	// tokens are the tokens of the text, tokenized from text by the first hashtag term if they are not set.
+	tokens []text.Token
+	text   string
+	// code is the buffer of the Soundex codes of the words.
+	code []byte
+}
+
+// wordsBetween returns the number of words of the text from the word i to the word j, excluded,
+// skipping the empty ones.
+func (c *queryContext) wordsBetween(i, j int) int {
+	res := 0
+	for _, w := range c.words[i:j] {
+		if w != "" {
+			res++
+		}
+	}
+	return res
+}
+
+// hashtagAt returns true if the raw token i of the text is a hashtag.
+func (c *queryContext) hashtagAt(i int) bool {
+	if c.tokens == nil {
+		c.tokens = text.Tokenize(c.text)
+	}
+	return i < len(c.tokens) && strings.HasPrefix(c.tokens[i].Raw, "#")
+}
+
+type queryNode interface {
+	matches(c *queryContext) bool
+	// appendSpans appends to dst the positions and lengths of the words of the text matched by the terms
+	// of the node, except the negated ones.
+	appendSpans(dst [][2]int, c *queryContext) [][2]int
+}
+
+// termNode is a word, a phrase, a wildcard pattern or a hashtag.
+type termNode struct {
+	// words are the processed words of the term, the pattern of a wildcard term.
+	words    []string
+	phonetic bool
+	// modified is true if the term has an explicit matching modifier.
+	modified bool
+	wildcard bool
+	hashtag  bool
+	// codes are the Soundex codes of the words of a phonetic term.
+	codes []string
+}
+
+func (t *termNode) matches(c *queryContext) bool {
+	for i := range c.words {
+		if _, ok := t.matchAt(c, i); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchAt returns the number of words of the text matched by the term from the word i, skipping the empty words
+// within a phrase like `phraseAt`. It returns false if the term does not start at the word i.
+func (t *termNode) matchAt(c *queryContext, i int) (int, bool) {
+	if c.words[i] == "" || (t.hashtag && !c.hashtagAt(i)) {
+		return 0, false
+	}
+	if t.wildcard {
+		return 1, matchWildcard(t.words[0], c.words[i])
+	}
+	j := i
+	for k, w := range t.words {
+		for j < len(c.words) && c.words[j] == "" {
+			j++
+		}
+		if j == len(c.words) {
+			return 0, false
+		}
+		if word := c.words[j]; word != w {
+			if !t.phonetic || t.codes[k] == "" {
+				return 0, false
+			}
+			if c.code = text.AppendSoundex(c.code[:0], word); string(c.code) != t.codes[k] {
+				return 0, false
+			}
+		}
+		j++
+	}
+	return j - i, true
+}
+
+func (t *termNode) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	for i := range c.words {
+		if n, ok := t.matchAt(c, i); ok {
+			dst = append(dst, [2]int{i, n})
+		}
+	}
+	return dst
+}
+
+type andNode []queryNode
+
+func (n andNode) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	for _, e := range n {
+		dst = e.appendSpans(dst, c)
+	}
+	return dst
+}
+
+func (n andNode) matches(c *queryContext) bool {
+	for _, e := range n {
+		if !e.matches(c) {
+			return false
+		}
+	}
+	return true
+}
+
+type orNode []queryNode
+
+func (n orNode) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	for _, e := range n {
+		dst = e.appendSpans(dst, c)
+	}
+	return dst
+}
+
+func (n orNode) matches(c *queryContext) bool {
+	for _, e := range n {
+		if e.matches(c) {
+			return true
+		}
+	}
+	return false
+}
+
+type notNode struct {
+	node queryNode
+}
+
+func (n notNode) matches(c *queryContext) bool {
+	return !n.node.matches(c)
+}
+
+func (n notNode) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	return dst
+}
+
+// nearNode are two terms with at most distance words between them, in any order.
+type nearNode struct {
+	left, right *termNode
+	distance    int
+}
+
+func (n nearNode) matches(c *queryContext) bool {
+	for i := range c.words {
+		left, ok := n.left.matchAt(c, i)
+		if !ok {
+			continue
+		}
+		for j := range c.words {
+			right, ok := n.right.matchAt(c, j)
+			if !ok {
+				continue
+			}
+			gap := 0
+			switch {
+			case j >= i+left:
+				gap = c.wordsBetween(i+left, j)
+			case i >= j+right:
+				gap = c.wordsBetween(j+right, i)
+			}
+			if gap <= n.distance {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (n nearNode) appendSpans(dst [][2]int, c *queryContext) [][2]int {
+	return n.right.appendSpans(n.left.appendSpans(dst, c), c)
+}
+
+// matchWildcard returns true if the word matches the pattern, where '*' matches any sequence of characters
+// and '?' any single character.
+func matchWildcard(pattern, word string) bool {
+	p, w := 0, 0
+	star, next := -1, 0
+	for w < len(word) {
+		switch {
+		case p < len(pattern) && (pattern[p] == '?' || pattern[p] == word[w]):
+			p++
+			w++
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, w
+			p++
+		case star >= 0:
+			p = star + 1
+			next++
+			w = next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+type queryTokenKind int
+
+const (
+	wordToken queryTokenKind = iota
+	phraseToken
+	openToken
+	closeToken
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+	// modifier is the '=' or '~' prefix of a term, 0 if none.
+	modifier byte
+}
+
+// lexQuery splits a query into parentheses, words and quoted phrases.
+func lexQuery(query string) ([]queryToken, error) {
+	res := []queryToken{}
+	for i := 0; i < len(query); {
+		switch c := query[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			res = append(res, queryToken{kind: openToken, text: "("})
+			i++
+		case c == ')':
+			res = append(res, queryToken{kind: closeToken, text: ")"})
+			i++
+		default:
+			tk := queryToken{kind: wordToken}
+			if (c == '=' || c == '~') && i+1 < len(query) && query[i+1] != ' ' {
+				tk.modifier = c
+				i++
+			}
+			if query[i] == '"' {
+				end := strings.IndexByte(query[i+1:], '"')
+				if end < 0 {
+					return nil, fmt.Errorf("unterminated phrase")
+				}
+				tk.kind, tk.text = phraseToken, query[i+1:i+1+end]
+				i += end + 2
+			} else {
+				start := i
+				for i < len(query) && !strings.ContainsRune(" \t\n()\"", rune(query[i])) {
+					i++
+				}
+				tk.text = query[start:i]
+			}
+			res = append(res, tk)
+		}
+	}
+	return res, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) peek() (queryToken, bool) {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos], true
+	}
+	return queryToken{}, false
+}
+
+// operator returns true if the next token is the supplied operator.
+func (p *queryParser) operator(name string) bool {
+	tk, ok := p.peek()
+	return ok && tk.kind == wordToken && tk.modifier == 0 && (tk.text == name || (name == "NEAR" && strings.HasPrefix(tk.text, "NEAR/")))
+}
+
+func (p *queryParser) parseOr() (queryNode, error) {
+	res := orNode{}
+	for {
+		n, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		if !p.operator("OR") {
+			break
+		}
+		p.pos++
+	}
+	if len(res) == 1 {
+		return res[0], nil
+	}
+	return res, nil
+}
+
+func (p *queryParser) parseAnd() (queryNode, error) {
+	res := andNode{}
+	for {
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, n)
+		if p.operator("AND") {
+			p.pos++
+			continue
+		}
+		if tk, ok := p.peek(); !ok || tk.kind == closeToken || p.operator("OR") {
+			break
+		}
+	}
+	if len(res) == 1 {
+		return res[0], nil
+	}
+	return res, nil
+}
+
+func (p *queryParser) parseUnary() (queryNode, error) {
+	if p.operator("NOT") {
+		p.pos++
+		n, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+	n, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	if !p.operator("NEAR") {
+		return n, nil
+	}
+	distance := defaultNearDistance
+	if op := p.tokens[p.pos].text; op != "NEAR" {
+		if distance, err = strconv.Atoi(strings.TrimPrefix(op, "NEAR/")); err != nil || distance < 0 {
+			return nil, fmt.Errorf("invalid distance in %q", op)
+		}
+	}
+	p.pos++
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	lt, lok := n.(*termNode)
+	rt, rok := right.(*termNode)
+	if !lok || !rok || p.operator("NEAR") {
+		return nil, fmt.Errorf("the operands of NEAR must be terms")
+	}
+	return nearNode{left: lt, right: rt, distance: distance}, nil
+}
+
+func (p *queryParser) parsePrimary() (queryNode, error) {
+	tk, ok := p.peek()
+	switch {
+	case !ok:
+		return nil, fmt.Errorf("unexpected end of query")
+	case tk.kind == openToken:
+		p.pos++
+		n, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if tk, ok := p.peek(); !ok || tk.kind != closeToken {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return n, nil
+	case tk.kind == closeToken || p.operator("AND") || p.operator("OR") || p.operator("NEAR"):
+		return nil, fmt.Errorf("unexpected %q", tk.text)
+	}
+	p.pos++
+	t, err := newTermNode(tk)
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// newTermNode compiles a word or a phrase of a query.
+func newTermNode(tk queryToken) (*termNode, error) {
+	t := &termNode{modified: tk.modifier != 0}
+	raw := tk.text
+	if tk.kind == phraseToken {
+		t.phonetic = tk.modifier == '~'
+		for _, f := range strings.Fields(raw) {
+			if w := text.AppendWord(nil, f); len(w) > 0 {
+				t.words = append(t.words, string(w))
+			}
+		}
+	} else {
+		t.phonetic = tk.modifier != '='
+		if strings.HasPrefix(raw, "#") {
+			t.hashtag, raw = true, raw[1:]
+		}
+		if strings.ContainsAny(raw, "*?") {
+			if tk.modifier == '~' {
+				return nil, fmt.Errorf("wildcard term %q cannot be matched by Soundex code", tk.text)
+			}
+			t.wildcard, t.phonetic = true, false
+			t.words = []string{string(appendWildcard(nil, raw))}
+		} else if w := text.AppendWord(nil, raw); len(w) > 0 {
+			t.words = []string{string(w)}
+		}
+	}
+	if len(t.words) == 0 || t.words[0] == "" {
+		return nil, fmt.Errorf("term %q has no letters or digits", tk.text)
+	}
+	if t.phonetic {
+		for _, w := range t.words {
+			t.codes = append(t.codes, text.Soundex(w))
+		}
+	}
+	return t, nil
+}
+
+// appendWildcard appends the processed form of a wildcard pattern to dst, keeping its wildcards.
+func appendWildcard(dst []byte, raw string) []byte {
+	start := 0
+	for i := 0; i <= len(raw); i++ {
+		if i == len(raw) || raw[i] == '*' || raw[i] == '?' {
+			dst = text.AppendWord(dst, raw[start:i])
+			if i < len(raw) {
+				dst = append(dst, raw[i])
+			}
+			start = i + 1
+		}
+	}
+	return dst
+}
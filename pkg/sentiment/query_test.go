@@ -0,0 +1,110 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTopicQueryMatch(t *testing.T) {
+	type testCase struct {
+		query      string
+		textString string
+		expected   bool
+	}
+	testCases := []testCase{
+		{query: "covid", textString: "I hate covd", expected: true},
+		{query: "=covid", textString: "I hate covd", expected: false},
+		{query: "=covid", textString: "I hate COVID!", expected: true},
+		{query: "covid-19", textString: "the Covid-19 crisis", expected: true},
+		{query: "corona OR covid", textString: "the Corona crisis", expected: true},
+		{query: "corona covid", textString: "the Corona crisis", expected: false},
+		{query: "corona AND crisis", textString: "the Corona crisis", expected: true},
+		{query: "corona AND NOT crisis", textString: "the Corona crisis", expected: false},
+		{query: "(corona OR covid) NOT beer", textString: "a corona beer", expected: false},
+		{query: `"climate change"`, textString: "climate is changing", expected: false},
+		{query: `"climate change"`, textString: "Climate change is real", expected: true},
+		{query: `"climate change"`, textString: "Climate  change is real", expected: true},
+		{query: `~"climate change"`, textString: "climat chanje is real", expected: true},
+		{query: "vacc*", textString: "my vaccination is today", expected: true},
+		{query: "c?vid", textString: "my covid test", expected: true},
+		{query: "c?vid", textString: "my cvid test", expected: false},
+		{query: "#covid", textString: "my covid test", expected: false},
+		{query: "#covid", textString: "my test #Covid", expected: true},
+		{query: "#covid*", textString: "my test #covid19", expected: true},
+		{query: "vaccine NEAR/1 covid", textString: "the covid vaccine", expected: true},
+		{query: "vaccine NEAR/1 covid", textString: "covid is bad, the vaccine is good", expected: false},
+		{query: "vaccine NEAR covid", textString: "covid is bad, the vaccine is good", expected: true},
+		{query: `"climate change" NEAR/0 hoax`, textString: "climate change hoax", expected: true},
+		{query: `"climate change" NEAR/0 hoax`, textString: "climate  change  hoax", expected: true}}
+	for _, tc := range testCases {
+		q, err := ParseTopicQuery(tc.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if out := q.Match(tc.textString); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q on %q", tc.expected, out, tc.query, tc.textString)
+		}
+		if out := Check(tc.textString, MatchesTopic(q)); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q on %q", tc.expected, out, tc.query, tc.textString)
+		}
+	}
+}
+
+func TestParseTopicQueryErrors(t *testing.T) {
+	for _, query := range []string{"", "   ", `"climate change`, "(covid", "covid)", "covid OR", "AND covid",
+		"!!!", "~covid*", "(a OR b) NEAR c", "a NEAR/x b", "a NEAR b NEAR c"} {
+		if _, err := ParseTopicQuery(query); err == nil {
+			t.Errorf("Failed: expected an error for %q", query)
+		}
+	}
+}
+
+func TestCheckTopic(t *testing.T) {
+	type testCase struct {
+		topic    string
+		expected bool
+	}
+	testCases := []testCase{
+		{topic: "covid", expected: true},
+		{topic: "corona OR covid", expected: true},
+		{topic: "!!!", expected: true},
+		{topic: "(corona OR covid", expected: false},
+		{topic: `"climate change`, expected: false},
+		{topic: "covid OR", expected: false},
+		{topic: "~covid*", expected: false}}
+	for _, tc := range testCases {
+		if out := CheckTopic(tc.topic) == nil; out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.topic)
+		}
+	}
+}
+
+func TestValidTextWithTopicQuery(t *testing.T) {
+	type testCase struct {
+		textString string
+		topic      string
+		expected   bool
+	}
+	testCases := []testCase{
+		{textString: "I am happy about Corona", topic: "covid", expected: false},
+		{textString: "I am happy about Corona", topic: "corona OR covid", expected: true},
+		{textString: "I am happy about the #covid news", topic: "#covid", expected: true},
+		{textString: "I am happy about covid news", topic: "#covid", expected: false},
+		{textString: "I am happy about climate change", topic: `"climate change"`, expected: true},
+		{textString: "I am xyz about climate change", topic: `"climate change"`, expected: false},
+		{textString: "I am happy about covid", topic: "(corona OR covid", expected: false},
+		{textString: "I am happy about corona", topic: "(corona", expected: false}}
+	for _, tc := range testCases {
+		if out := ValidTextWithTopic(tc.textString, tc.topic); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q on %q", tc.expected, out, tc.topic, tc.textString)
+		}
+		if out := DiagnoseWithTopic(tc.textString, tc.topic).Has(MissingTopic); out == tc.expected && tc.expected {
+			t.Errorf("Failed: expected no missing topic for %q on %q", tc.topic, tc.textString)
+		}
+	}
+	texts := []string{"I am happy about Corona", "I am sad about covid", "I am happy", "covid xyz"}
+	expected := []string{"I am happy about Corona", "I am sad about covid"}
+	if out := ValidTextsWithTopic(texts, "corona OR covid"); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+}
@@ -0,0 +1,161 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Hierarchical aggregation. The weight with which a text counts in each category, resolved with the
+analyzer's strategy as in `CategoryWeights`, rolls down to the states of the category and up to the
+direction of the category and to the overall sentiment. A text counts at most once in a direction and
+overall, so that every value of a corpus rollup ranges from 0 to 1, like `AggregateCategories`.
+*/
+
+// Rollup is the sentiment of a text, or of a corpus of texts, at every level of the category hierarchy.
+type Rollup struct {
+	Texts int
+	// Overall is the weight with which the texts express any state.
+	Overall float64
+	// Directions, Categories and States are the weights with which the texts express each direction,
+	// category and state. The ones with no weight are omitted. The weight of a category is the sum of
+	// the ones of its states if the analyzer sums the weights, see `Analyzer.SumWeights`, and can then exceed 1.
+	Directions map[Direction]float64
+	Categories map[Category]float64
+	States     map[string]float64
+}
+
+func newRollup() Rollup {
+	return Rollup{Directions: map[Direction]float64{}, Categories: map[Category]float64{}, States: map[string]float64{}}
+}
+
+// TextRollup returns the sentiment of a text at every level of the category hierarchy,
+// resolved with the supplied strategy.
+func TextRollup(textString string, strategy ConflictStrategy) Rollup {
+	return Analyzer{Strategy: strategy}.Rollup(textString)
+}
+
+// AggregateRollup returns the aggregate sentiment of the texts at every level of the category hierarchy,
+// resolving multi-category texts with the supplied strategy. Each value ranges from 0 to 1.
+// The texts are expected to be already validated, see `ValidText`.
+func AggregateRollup(texts []string, strategy ConflictStrategy) (Rollup, error) {
+	return Analyzer{Strategy: strategy}.AggregateRollup(texts)
+}
+
+// Rollup returns the sentiment of a text at every level of the category hierarchy,
+// resolved with the analyzer's strategy.
+func (an Analyzer) Rollup(textString string) Rollup {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := newRollup()
+	sc.AddRollup(&res, textString)
+	return res
+}
+
+// AggregateRollup returns the aggregate sentiment of the texts at every level of the category hierarchy,
+// resolving multi-category texts with the analyzer's strategy. Each value ranges from 0 to 1.
+// The texts are expected to be already validated, see `Analyzer.ValidText`.
+func (an Analyzer) AggregateRollup(texts []string) (Rollup, error) {
+	if len(texts) == 0 {
+		return Rollup{}, fmt.Errorf("texts is empty")
+	}
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := newRollup()
+	for _, t := range texts {
+		sc.AddRollup(&res, t)
+	}
+	n := float64(len(texts))
+	res.Overall /= n
+	for d, s := range res.Directions {
+		res.Directions[d] = s / n
+	}
+	for c, s := range res.Categories {
+		res.Categories[c] = s / n
+	}
+	for st, s := range res.States {
+		res.States[st] = s / n
+	}
+	return res, nil
+}
+
+// AddRollup adds the text to the sums, at every level of the category hierarchy,
+// resolved with the scanner's analyzer strategy. See `Analyzer.Rollup`.
+// If the analyzer is weighted, every state counts with its share of its category scaled by its weight,
+// and every category with its share scaled by the weight of its most intense state, or by the sum of the
+// weights of its states if the analyzer sums them, see `Analyzer.SumWeights`.
+func (sc *Scanner) AddRollup(sums *Rollup, textString string) {
+	sc.counted = sc.Analyzer.appendCountedStates(sc.counted[:0], *sc.Analyze(textString))
+	shares := map[Category]float64{}
+	sc.resolver.addTo(shares, sc.counted, Analyzer{Strategy: sc.Analyzer.Strategy})
+	sums.Texts++
+	if len(shares) == 0 {
+		return
+	}
+	lex, lexicon := sc.Analyzer.lexicon(), sc.Analyzer.weights()
+	weight := func(state string) float64 {
+		if lexicon == nil {
+			return 1
+		}
+		return lexicon[state].weight()
+	}
+	intensities := map[Category]float64{}
+	for j, m := range sc.counted {
+		switch w := weight(m.State); {
+		case sc.Analyzer.SumWeights:
+			if !matchedState(sc.counted[:j], m.State) {
+				intensities[m.Category] += w
+			}
+		case w > intensities[m.Category]:
+			intensities[m.Category] = w
+		}
+	}
+	directions := map[Direction]float64{}
+	states := map[string]bool{}
+	// a category only counts once in every direction, however many of its states are matched
+	categories := map[categoryDirection]bool{}
+	for _, m := range sc.counted {
+		share := shares[m.Category]
+		if share == 0 || states[m.State] {
+			continue
+		}
+		states[m.State] = true
+		sums.States[m.State] += share * weight(m.State)
+		if cd := (categoryDirection{m.Category, rollupDirection(lex, m)}); !categories[cd] {
+			categories[cd] = true
+			directions[cd.Direction] += share * intensities[m.Category]
+		}
+	}
+	overall := 0.0
+	for c, share := range shares {
+		w := share * intensities[c]
+		sums.Categories[c] += w
+		overall += w
+	}
+	for d, w := range directions {
+		sums.Directions[d] += capWeight(w)
+	}
+	sums.Overall += capWeight(overall)
+}
+
+type categoryDirection struct {
+	Category  Category
+	Direction Direction
+}
+
+// rollupDirection returns the direction under which a match rolls up: the one of its category in the lexicon,
+// or its own one for the categories with no direction.
+func rollupDirection(lex *Lexicon, m StateMatch) Direction {
+	if d, ok := lex.CategoryDirection(m.Category); ok {
+		return d
+	}
+	return m.Direction
+}
+
+// capWeight caps the weight of a text at 1, as a text that expresses several categories of a direction
+// counts only once in that direction.
+func capWeight(w float64) float64 {
+	if w > 1 {
+		return 1
+	}
+	return w
+}
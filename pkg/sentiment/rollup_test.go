@@ -0,0 +1,69 @@
+package sentiment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRollup(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   Rollup
+	}
+	testCases := []testCase{
+		{analyzer: Analyzer{}, textString: "I am xyz", expected: Rollup{Texts: 1,
+			Directions: map[Direction]float64{}, Categories: map[Category]float64{}, States: map[string]float64{}}},
+		{analyzer: Analyzer{}, textString: "I am sad, happy and joyful", expected: Rollup{Texts: 1, Overall: 1,
+			Directions: map[Direction]float64{Negative: 1, Positive: 1},
+			Categories: map[Category]float64{Sadness: 1, Joviality: 1},
+			States:     map[string]float64{"sad": 1, "happy": 1, "joyful": 1}}},
+		{analyzer: Analyzer{Strategy: Fractional}, textString: "I am sad, happy and tired", expected: Rollup{Texts: 1, Overall: 1,
+			Directions: map[Direction]float64{Negative: 1.0 / 3, Positive: 1.0 / 3, Other: 1.0 / 3},
+			Categories: map[Category]float64{Sadness: 1.0 / 3, Joviality: 1.0 / 3, Fatigue: 1.0 / 3},
+			States:     map[string]float64{"sad": 1.0 / 3, "happy": 1.0 / 3, "tired": 1.0 / 3}}},
+		{analyzer: Analyzer{Strategy: DropConflicting}, textString: "I am sad and happy", expected: Rollup{Texts: 1,
+			Directions: map[Direction]float64{}, Categories: map[Category]float64{}, States: map[string]float64{}}},
+		{analyzer: Analyzer{Lexicon: IPANASSF}, textString: "I am upset and inspired", expected: Rollup{Texts: 1, Overall: 1,
+			Directions: map[Direction]float64{Negative: 1, Positive: 1},
+			Categories: map[Category]float64{General: 1},
+			States:     map[string]float64{"upset": 1, "inspired": 1}}}}
+	for _, tc := range testCases {
+		out := tc.analyzer.Rollup(tc.textString)
+		if !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", tc.expected, out)
+		}
+	}
+}
+
+func TestAggregateRollup(t *testing.T) {
+	texts := []string{"I am happy", "I am happy and joyful", "I am tired", "I am xyz"}
+	expected := Rollup{Texts: 4, Overall: 0.75,
+		Directions: map[Direction]float64{Positive: 0.5, Other: 0.25},
+		Categories: map[Category]float64{Joviality: 0.5, Fatigue: 0.25},
+		States:     map[string]float64{"happy": 0.5, "joyful": 0.25, "tired": 0.25}}
+	out, err := AggregateRollup(texts, CountAll)
+	if err != nil || !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	if _, err := AggregateRollup([]string{}, CountAll); err == nil {
+		t.Errorf("Failed: expected an error for no texts")
+	}
+}
+
+func TestRollupCustomCategory(t *testing.T) {
+	l, err := NewLexiconWith("work", []string{"frustrated", "happy"}, map[string]StateC{
+		"frustrated": {Category: "frustration", Direction: Negative},
+		"happy":      {Category: Joviality, Direction: Positive}},
+		LexiconOptions{Categories: map[Category]Direction{"frustration": Negative}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Rollup{Texts: 1, Overall: 1,
+		Directions: map[Direction]float64{Negative: 1, Positive: 1},
+		Categories: map[Category]float64{"frustration": 1, Joviality: 1},
+		States:     map[string]float64{"frustrated": 1, "happy": 1}}
+	if out := (Analyzer{Lexicon: l}).Rollup("I am frustrated and happy"); !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+}
@@ -0,0 +1,188 @@
+package sentiment
+
+import (
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Composable validation rules. A `Rule` is a predicate on the analysis of a text, evaluated with the settings
+of an analyzer; rules are combined with `And`, `Or` and `Not`. The checks of `ValidText` and
+`ValidTextWithTopic` are the predefined rules `SelfRefAndState` and `WithTopic`.
+*/
+
+// Rule decides whether the analysis of a text is valid, according to the analyzer.
+type Rule func(an Analyzer, a Analysis) bool
+
+// And returns the rule satisfied by the analyses that satisfy all the rules.
+func And(rules ...Rule) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, r := range rules {
+			if !r(an, a) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Or returns the rule satisfied by the analyses that satisfy at least one of the rules.
+func Or(rules ...Rule) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, r := range rules {
+			if r(an, a) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns the rule satisfied by the analyses that do not satisfy the rule.
+func Not(rule Rule) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		return !rule(an, a)
+	}
+}
+
+// HasSelfRef returns the rule satisfied by the analyses with a self reference counted by the analyzer.
+func HasSelfRef() Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, m := range a.SelfRefs {
+			if an.countsSelfRef(m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// HasState returns the rule satisfied by the analyses with a state counted by the analyzer,
+// in one of the supplied categories if any.
+func HasState(categories ...Category) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, m := range a.States {
+			if (len(categories) == 0 || containsCategory(categories, m.Category)) && an.countsState(m) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func containsCategory(coll []Category, c Category) bool {
+	for _, e := range coll {
+		if e == c {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTopic returns the rule satisfied by the analyses that match the topic, like in `ValidTextWithTopic`.
+// The topic can be a topic query, see `ParseTopicQuery`. A topic with no letters or digits is never matched.
+func HasTopic(topic string) Rule {
+	if q := compileTopic(topic); q != nil {
+		return MatchesTopic(q)
+	}
+	m := newMatcher(nil, []string{topic})
+	return Or(topicHit(topic), func(an Analyzer, a Analysis) bool {
+		return containsKind(m.Hits(a.Words), TopicHit)
+	})
+}
+
+// topicHit returns the rule satisfied by the analyses made with the topic that contain it,
+// such as the ones of `Scanner.ValidTextWithTopic`.
+func topicHit(topic string) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, h := range a.Hits {
+			if h.Kind == TopicHit && h.Pattern == topic {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// MinWords returns the rule satisfied by the analyses of the texts with at least n words.
+func MinWords(n int) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		return len(a.Words) >= n
+	}
+}
+
+// englishWords are the words of the bundled word list.
+var englishWords = func() map[string]bool {
+	res := map[string]bool{}
+	for _, wf := range BundledWordList() {
+		res[string(text.AppendWord(nil, wf.Word))] = true
+	}
+	return res
+}()
+
+// InEnglish returns the rule satisfied by the analyses of the texts whose share of common English words,
+// the words of `BundledWordList`, is at least minShare. Texts with no words are not in English.
+func InEnglish(minShare float64) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		if len(a.Words) == 0 {
+			return false
+		}
+		common := 0
+		for _, w := range a.Words {
+			if englishWords[w] {
+				common++
+			}
+		}
+		return float64(common)/float64(len(a.Words)) >= minShare
+	}
+}
+
+// NotURLOnly returns the rule satisfied by the texts with content other than links.
+func NotURLOnly() Rule {
+	return func(an Analyzer, a Analysis) bool {
+		for _, f := range strings.Fields(a.Text) {
+			if !isURL(f) && len(text.AppendWord(nil, f)) > 0 {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func isURL(field string) bool {
+	f := strings.ToLower(field)
+	return strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://") || strings.HasPrefix(f, "www.")
+}
+
+// MinConfidence returns the rule satisfied by the analyses whose confidence is at least t, see `Analyzer.Confidence`.
+func MinConfidence(t float64) Rule {
+	return func(an Analyzer, a Analysis) bool {
+		return an.Confidence(a) >= t
+	}
+}
+
+// SelfRefAndState is the rule of `ValidText`: a counted self reference and a counted state.
+var SelfRefAndState = And(HasSelfRef(), HasState())
+
+// WithTopic returns the rule of `ValidTextWithTopic`: the topic, a counted self reference and a counted state.
+func WithTopic(topic string) Rule {
+	return And(HasTopic(topic), SelfRefAndState)
+}
+
+// Check returns true if the text satisfies the rule, see `Analyzer.Check`.
+func Check(textString string, rule Rule) bool {
+	return Analyzer{}.Check(textString, rule)
+}
+
+// Check returns true if the text satisfies the rule, according to the analyzer.
+func (an Analyzer) Check(textString string, rule Rule) bool {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	return sc.Check(textString, rule)
+}
+
+// Check returns true if the text satisfies the rule, according to the scanner's analyzer.
+func (sc *Scanner) Check(textString string, rule Rule) bool {
+	return rule(sc.Analyzer, *sc.Analyze(textString))
+}
@@ -0,0 +1,54 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	type testCase struct {
+		rule       Rule
+		textString string
+		expected   bool
+	}
+	testCases := []testCase{
+		{rule: SelfRefAndState, textString: "I am happy", expected: true},
+		{rule: SelfRefAndState, textString: "I am xyz", expected: false},
+		{rule: HasSelfRef(), textString: "I am xyz", expected: true},
+		{rule: HasState(), textString: "happy day", expected: true},
+		{rule: HasState(Sadness, Fear), textString: "I am happy", expected: false},
+		{rule: HasState(Sadness, Fear), textString: "I am scared", expected: true},
+		{rule: HasTopic("covid"), textString: "I am happy about covid", expected: true},
+		{rule: HasTopic("covid"), textString: "I am happy", expected: false},
+		{rule: HasTopic("!!!"), textString: "I am happy", expected: false},
+		{rule: MinWords(3), textString: "I am happy", expected: true},
+		{rule: MinWords(4), textString: "I am happy", expected: false},
+		{rule: InEnglish(0.5), textString: "I think that you are right", expected: true},
+		{rule: InEnglish(0.5), textString: "je suis tres content", expected: false},
+		{rule: InEnglish(0.5), textString: "", expected: false},
+		{rule: NotURLOnly(), textString: "https://t.co/xyz www.example.com", expected: false},
+		{rule: NotURLOnly(), textString: "I am happy https://t.co/xyz", expected: true},
+		{rule: MinConfidence(0.9), textString: "I am happy", expected: true},
+		{rule: MinConfidence(0.9), textString: "I am hapy", expected: false},
+		{rule: Or(HasState(Sadness), HasState(Joviality)), textString: "I am happy", expected: true},
+		{rule: And(SelfRefAndState, Not(HasState(Joviality))), textString: "I am happy", expected: false},
+		{rule: And(SelfRefAndState, Not(HasState(Joviality))), textString: "I am sad", expected: true},
+		{rule: WithTopic("covid"), textString: "I am happy about covid", expected: true},
+		{rule: WithTopic("covid"), textString: "covid is here", expected: false}}
+	for _, tc := range testCases {
+		if out := Check(tc.textString, tc.rule); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestRuleAnalyzer(t *testing.T) {
+	an := Analyzer{ExcludeNonAssertive: true}
+	if an.Check("am I happy?", SelfRefAndState) || !Check("am I happy?", SelfRefAndState) {
+		t.Errorf("Failed: expected the rule to use the settings of the analyzer")
+	}
+	for _, s := range []string{"I am happy", "I am xyz", "am I happy?"} {
+		if an.Check(s, SelfRefAndState) != an.ValidText(s) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", an.ValidText(s), an.Check(s, SelfRefAndState), s)
+		}
+	}
+}
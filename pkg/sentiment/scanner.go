@@ -0,0 +1,145 @@
+package sentiment
+
+import (
+	"sync"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Allocation-free hot path. A `Scanner` keeps the buffers of the tokenizer, of the analysis and of the
+conflict resolution between texts, so that, once they have grown, analyzing a text only allocates the
+processed words that differ from their raw form (a single allocation per text).
+The package-level functions and the `Analyzer` methods borrow scanners from a pool.
+*/
+
+// Scanner analyzes texts reusing its buffers between calls. The zero value is ready to use.
+// A Scanner is not safe for concurrent use; use one Scanner per goroutine.
+type Scanner struct {
+	// Analyzer decides which matches are counted.
+	Analyzer  Analyzer
+	tokenizer text.Tokenizer
+	analysis  Analysis
+	counted   []StateMatch
+	resolver  resolver
+	// tokens are the tokens of the last analyzed text, with their infos.
+	tokens []text.Token
+	infos  []tokenInfo
+	// query is the last analyzed text as matched by the topic queries, reusing its Soundex buffer.
+	query queryContext
+	// topic and topicLexicon are the topic and the lexicon of the last call to `ValidTextWithTopic`:
+	// topicMatcher matches the states of the lexicon, along with the topic if it is a plain word,
+	// and topicQuery is the query of the topic otherwise.
+	topic        string
+	topicLexicon *Lexicon
+	topicMatcher *Matcher
+	topicQuery   *TopicQuery
+}
+
+// Analyze returns the detailed analysis of a text, with the states of the analyzer's lexicon.
+// The analysis belongs to the scanner, and is only valid until the next call.
+func (sc *Scanner) Analyze(textString string) *Analysis {
+	lex := sc.Analyzer.lexicon()
+	return sc.analyze(textString, lex.matcher, lex)
+}
+
+// analyze returns the detailed analysis of a text, with the hits of the supplied matcher,
+// whose states are the ones of the lexicon.
+func (sc *Scanner) analyze(textString string, m *Matcher, lex *Lexicon) *Analysis {
+	a := &sc.analysis
+	tokens := sc.tokenizer.Tokenize(textString)
+	sc.tokens = tokens
+	a.Text = textString
+	a.Words = a.Words[:0]
+	for _, tk := range tokens {
+		a.Words = append(a.Words, tk.Word)
+	}
+	sc.infos = appendTokenInfos(sc.infos, tokens)
+	annotate(a, tokens, sc.infos)
+	a.Hits = m.AppendHits(a.Hits[:0], a.Words)
+	a.SelfRefs = appendSelfRefMatches(a.SelfRefs[:0], m, a.Hits)
+	for i := range a.SelfRefs {
+		a.SelfRefs[i].Speech = a.Speech[a.SelfRefs[i].Position]
+	}
+	a.States = appendStateMatches(a.States[:0], m, a.Hits, a.Words, lex)
+	a.Links = a.Links[:0]
+	for i := range a.States {
+		p := a.States[i].Position
+		a.States[i].Speech = a.Speech[p]
+		a.States[i].Modality = a.Modality[p]
+		a.States[i].Confidence *= modalityConfidence[a.Modality[p]]
+		a.States[i].Tense = a.Tense[p]
+		a.States[i].Attribution = a.Attribution[p]
+		a.States[i].Negated = a.Negated[p]
+	}
+	return a
+}
+
+// ValidText returns true if it finds the text to be valid to be considered for sentiment analysis,
+// according to the scanner's analyzer. See `Analyzer.ValidText`.
+func (sc *Scanner) ValidText(textString string) bool {
+	return sc.Analyzer.valid(*sc.Analyze(textString))
+}
+
+// ValidTextWithTopic returns true if it finds the text to be valid to be considered for sentiment analysis on a topic,
+// according to the scanner's analyzer. See `Analyzer.ValidTextWithTopic`.
+// The topic is compiled along with the base data, once for consecutive calls with the same topic.
+func (sc *Scanner) ValidTextWithTopic(textString, topic string) bool {
+	a := sc.analyzeWithTopic(textString, topic)
+	return sc.hasTopic(a) && sc.Analyzer.valid(*a)
+}
+
+// analyzeWithTopic returns the detailed analysis of a text, with the states of the analyzer's lexicon and the topic.
+func (sc *Scanner) analyzeWithTopic(textString, topic string) *Analysis {
+	lex := sc.Analyzer.lexicon()
+	if sc.topicMatcher == nil || sc.topic != topic || sc.topicLexicon != lex {
+		sc.topic, sc.topicLexicon = topic, lex
+		sc.topicQuery = compileTopic(topic)
+		if sc.topicQuery != nil {
+			sc.topicMatcher = lex.matcher
+		} else {
+			// the analyses are made with the topic, so that its hits are enough
+			sc.topicMatcher = newMatcher(lex.States, []string{topic})
+		}
+	}
+	return sc.analyze(textString, sc.topicMatcher, lex)
+}
+
+// hasTopic returns true if the analysis, made with the topic by `analyzeWithTopic`, matches the topic.
+func (sc *Scanner) hasTopic(a *Analysis) bool {
+	if q := sc.topicQuery; q != nil {
+		return q.matches(sc.queryContext(a))
+	}
+	for _, h := range a.Hits {
+		if h.Kind == TopicHit && h.Pattern == sc.topic {
+			return true
+		}
+	}
+	return false
+}
+
+// queryContext returns the text of the analysis, the last analyzed one, as matched by the queries.
+func (sc *Scanner) queryContext(a *Analysis) *queryContext {
+	sc.query.words, sc.query.tokens, sc.query.text = a.Words, sc.tokens, a.Text
+	return &sc.query
+}
+
+// AddCategoryWeights adds to sums the weight with which the text counts in each category,
+// resolved with the scanner's analyzer strategy. See `Analyzer.CategoryWeights`.
+func (sc *Scanner) AddCategoryWeights(sums map[Category]float64, textString string) {
+	sc.counted = sc.Analyzer.appendCountedStates(sc.counted[:0], *sc.Analyze(textString))
+	sc.resolver.addTo(sums, sc.counted, sc.Analyzer)
+}
+
+var scannerPool = sync.Pool{New: func() interface{} { return new(Scanner) }}
+
+func getScanner(an Analyzer) *Scanner {
+	sc := scannerPool.Get().(*Scanner)
+	sc.Analyzer = an
+	return sc
+}
+
+func putScanner(sc *Scanner) {
+	sc.Analyzer = Analyzer{}
+	scannerPool.Put(sc)
+}
@@ -0,0 +1,146 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+// benchmarkTexts are tweet-like texts, mixing valid and invalid ones.
+var benchmarkTexts = []string{
+	"I am so happy that covid is getting under control!",
+	"This is covid time, stay home and stay safe.",
+	`RT @anna: "I am scared" is all I could say`,
+	"Feeling tired and sluggish today, I need a coffee",
+	"am I happy? I don't know, maybe I'll be calm tomorrow",
+	"my mom is scared but I am confident we will be fine",
+}
+
+func TestScanner(t *testing.T) {
+	var sc Scanner
+	for _, s := range benchmarkTexts {
+		if sc.ValidText(s) != ValidText(s) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", ValidText(s), sc.ValidText(s), s)
+		}
+		a := Analyze(s)
+		b := sc.Analyze(s)
+		if len(a.States) != len(b.States) || len(a.SelfRefs) != len(b.SelfRefs) {
+			t.Errorf("Failed: expected %v, recieved %v", a, *b)
+		}
+		for i := range a.States {
+			if a.States[i] != b.States[i] {
+				t.Errorf("Failed: expected %v, recieved %v", a.States[i], b.States[i])
+			}
+		}
+	}
+}
+
+func TestScannerAllocations(t *testing.T) {
+	var sc Scanner
+	for _, s := range benchmarkTexts {
+		sc.ValidText(s)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, s := range benchmarkTexts {
+			sc.ValidTextWithTopic(s, "covid")
+		}
+	})
+	// at most one allocation per text, for its processed words
+	if allocs > float64(len(benchmarkTexts)) {
+		t.Errorf("Failed: expected at most %v allocations, recieved %v", len(benchmarkTexts), allocs)
+	}
+}
+
+func TestScannerTopicQueryAllocations(t *testing.T) {
+	var sc Scanner
+	topic := `~"climate change" OR #covid OR corona*`
+	for _, s := range benchmarkTexts {
+		sc.ValidTextWithTopic(s, topic)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, s := range benchmarkTexts {
+			sc.ValidTextWithTopic(s, topic)
+		}
+	})
+	// at most one allocation per text, for its processed words
+	if allocs > float64(len(benchmarkTexts)) {
+		t.Errorf("Failed: expected at most %v allocations, recieved %v", len(benchmarkTexts), allocs)
+	}
+}
+
+func TestScannerAnalyzeAllocations(t *testing.T) {
+	var sc Scanner
+	for _, s := range benchmarkTexts {
+		sc.Analyze(s)
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		for _, s := range benchmarkTexts {
+			sc.Analyze(s)
+		}
+	})
+	// at most one allocation per text, for its processed words
+	if allocs > float64(len(benchmarkTexts)) {
+		t.Errorf("Failed: expected at most %v allocations, recieved %v", len(benchmarkTexts), allocs)
+	}
+}
+
+func benchmarkBytes() int64 {
+	n := 0
+	for _, s := range benchmarkTexts {
+		n += len(s)
+	}
+	return int64(n)
+}
+
+func BenchmarkScannerValidText(b *testing.B) {
+	var sc Scanner
+	b.SetBytes(benchmarkBytes())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkTexts {
+			sc.ValidText(s)
+		}
+	}
+}
+
+func BenchmarkValidText(b *testing.B) {
+	b.SetBytes(benchmarkBytes())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkTexts {
+			ValidText(s)
+		}
+	}
+}
+
+// BenchmarkValidTextParallel reports the throughput with one goroutine per core.
+func BenchmarkValidTextParallel(b *testing.B) {
+	b.SetBytes(benchmarkBytes())
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		var sc Scanner
+		for pb.Next() {
+			for _, s := range benchmarkTexts {
+				sc.ValidText(s)
+			}
+		}
+	})
+}
+
+func BenchmarkAggregateCategories(b *testing.B) {
+	b.SetBytes(benchmarkBytes())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := AggregateCategories(benchmarkTexts, Fractional); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkAnalyze(b *testing.B) {
+	b.SetBytes(benchmarkBytes())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, s := range benchmarkTexts {
+			Analyze(s)
+		}
+	}
+}
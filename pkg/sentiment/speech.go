@@ -0,0 +1,146 @@
+package sentiment
+
+import (
+	"fmt"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Detection of the words that are not the author's own statements: quoted spans, retweeted texts,
+reply quotes and reported speech. Self references and states found in such words describe
+someone else's feelings, and are not counted unless `Analyzer.IncludeReported` is set.
+*/
+
+// Speech is the kind of speech a word belongs to.
+type Speech int
+
+const (
+	// Direct is the author's own statement.
+	Direct Speech = iota
+	// Quoted is a word inside quotation marks, or inside a reply quote (a line starting with ">").
+	Quoted
+	// Retweeted is a word following a retweet prefix, such as "RT @user:".
+	Retweeted
+	// Reported is a word following a reported speech marker, such as "she said" or "he told me".
+	Reported
+)
+
+var speechNames = map[Speech]string{
+	Direct:    "direct",
+	Quoted:    "quoted",
+	Retweeted: "retweeted",
+	Reported:  "reported",
+}
+
+// String returns the name of the speech kind.
+func (s Speech) String() string {
+	if name, ok := speechNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("Speech(%d)", int(s))
+}
+
+// retweetPrefixes are the tokens that introduce a retweeted or modified-tweet text, when followed by a handle.
+var retweetPrefixes = map[string]bool{"rt": true, "mt": true}
+
+// reportingVerbs are the verbs that introduce reported speech.
+var reportingVerbs = map[string]bool{
+	"said": true, "says": true, "say": true, "told": true, "tells": true, "tell": true,
+	"claims": true, "claimed": true, "thinks": true, "thought": true, "believes": true,
+	"wrote": true, "writes": true, "asked": true, "asks": true, "replied": true, "replies": true,
+	"explained": true, "insisted": true, "whispered": true, "shouted": true, "yelled": true,
+	"screamed": true, "admitted": true, "texted": true, "tweeted": true, "posted": true,
+}
+
+// reportingSubjects are the words that, right before a reporting verb, make it someone else's speech.
+var reportingSubjects = map[string]bool{
+	"he": true, "she": true, "they": true, "you": true, "someone": true, "somebody": true,
+	"everyone": true, "everybody": true, "people": true, "who": true,
+}
+
+// determiners are the words that, two positions before a reporting verb, introduce a noun subject ("my mom said").
+var determiners = map[string]bool{
+	"my": true, "his": true, "her": true, "their": true, "your": true, "our": true,
+	"the": true, "a": true, "an": true, "this": true, "that": true,
+}
+
+// closingQuote returns the closing quotation mark matching an opening one, 0 if r is not an opening quotation mark.
+func closingQuote(r rune) rune {
+	switch r {
+	case '"':
+		return '"'
+	case '“':
+		return '”'
+	case '„':
+		return '“'
+	case '«':
+		return '»'
+	}
+	return 0
+}
+
+// DetectSpeech returns the kind of speech of every token of a text.
+// The result is aligned with the output of `text.GenerateValidWords`.
+func DetectSpeech(textString string) []Speech {
+	return annotateText(textString).Speech
+}
+
+// reportedSubject checks if the reporting verb at position i is preceded by someone else than the author.
+func reportedSubject(tokens []text.Token, infos []tokenInfo, i int) bool {
+	if i == 0 {
+		return false
+	}
+	prev := tokens[i-1]
+	if infos[i-1].roles&reportingSubjectRole != 0 {
+		return true
+	}
+	if prev.Word == "" || prev.Word == "i" || prev.Word == "we" || infos[i-1].marks&clauseMark != 0 {
+		return false
+	}
+	if i >= 2 && infos[i-2].roles&determinerRole != 0 {
+		return true
+	}
+	// a capitalised name, such as "Anna said"
+	first := prev.Raw[0]
+	return first >= 'A' && first <= 'Z'
+}
+
+// quoteState tracks the quotation marks and the reply quotes of the tokens of a text.
+type quoteState struct {
+	closing            rune
+	midLine, replyLine bool
+}
+
+// next returns `Quoted` if the first alphanumeric character of the next raw token is inside quotation marks
+// or on a line that starts with ">", and `Direct` otherwise.
+func (q *quoteState) next(raw string) Speech {
+	speech, found := Direct, false
+	for j, r := range raw {
+		switch {
+		case r == '\n':
+			q.midLine, q.replyLine = false, false
+			continue
+		case !q.midLine && r == '>':
+			q.replyLine = true
+		case q.closing != 0 && r == q.closing:
+			q.closing = 0
+		case q.closing == 0 && closingQuote(r) != 0:
+			q.closing = closingQuote(r)
+		}
+		if r != '\t' {
+			q.midLine = true
+		}
+		if !found && isAlnum(raw[j]) {
+			found = true
+			if q.closing != 0 || q.replyLine {
+				speech = Quoted
+			}
+		}
+	}
+	return speech
+}
+
+func isAlnum(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,36 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestDetectSpeech(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []Speech
+	}
+	cases := []testCase{
+		{textString: "I am happy", expected: []Speech{Direct, Direct, Direct}},
+		{textString: `she wrote "I am sad" today`, expected: []Speech{Direct, Reported, Quoted, Quoted, Quoted, Reported}},
+		{textString: `the sign read "I am sad" today`, expected: []Speech{Direct, Direct, Direct, Quoted, Quoted, Quoted, Direct}},
+		{textString: "so true RT @anna: I am sad", expected: []Speech{Direct, Direct, Retweeted, Retweeted, Retweeted, Retweeted, Retweeted}},
+		{textString: "he told me he is angry. I am calm", expected: []Speech{Direct, Reported, Reported, Reported, Reported, Reported, Direct, Direct, Direct}},
+		{textString: "my mom said she is scared", expected: []Speech{Direct, Direct, Reported, Reported, Reported, Reported}},
+		{textString: "I said I am fine", expected: []Speech{Direct, Direct, Direct, Direct, Direct}},
+		{textString: "> I am sad\nme too", expected: []Speech{Direct, Quoted, Quoted, Quoted, Direct}},
+		{textString: "“I’m scared” I am not", expected: []Speech{Quoted, Quoted, Direct, Direct, Direct}}}
+
+	for _, c := range cases {
+		out := DetectSpeech(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+				break
+			}
+		}
+	}
+}
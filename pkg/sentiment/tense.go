@@ -0,0 +1,89 @@
+package sentiment
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/coderafting/panas-go/internal/text"
+)
+
+/*
+Lightweight tense classification of the words: PANAS-t measures the current mood, so "I was so sad last year"
+and "I'll be nervous tomorrow" should be told apart from "I'm sad". The nearest auxiliary verb before a word
+in its clause decides the tense; without one, a time adverbial anywhere in the clause does.
+Words with neither marker are considered to be in the present.
+*/
+
+// Tense is the temporal reference of the clause a word belongs to.
+type Tense int
+
+const (
+	// Present is the current state of the author, such as "I'm sad".
+	Present Tense = iota
+	// Past is a former state, such as "I was so sad last year".
+	Past
+	// Future is an expected state, such as "I'll be nervous tomorrow".
+	Future
+)
+
+var tenseNames = map[Tense]string{
+	Present: "present",
+	Past:    "past",
+	Future:  "future",
+}
+
+// String returns the name of the tense.
+func (t Tense) String() string {
+	if name, ok := tenseNames[t]; ok {
+		return name
+	}
+	return fmt.Sprintf("Tense(%d)", int(t))
+}
+
+// tenseAuxiliaries are the verbs that set the tense of the words following them in a clause.
+var tenseAuxiliaries = map[string]Tense{
+	"am": Present, "im": Present, "is": Present, "isnt": Present, "are": Present, "arent": Present, "aint": Present,
+	"feel": Present, "feels": Present, "have": Present, "has": Present, "ive": Present,
+	"was": Past, "were": Past, "wasnt": Past, "werent": Past, "had": Past, "hadnt": Past,
+	"felt": Past, "did": Past, "didnt": Past, "used": Past, "became": Past, "got": Past,
+	"will": Future, "wont": Future, "shall": Future, "gonna": Future,
+}
+
+// timeAdverbials are the words that set the tense of a clause with no auxiliary verb.
+var timeAdverbials = map[string]Tense{
+	"now": Present, "today": Present, "currently": Present, "lately": Present, "nowadays": Present,
+	"yesterday": Past, "ago": Past, "last": Past, "earlier": Past, "previously": Past, "formerly": Past,
+	"tomorrow": Future, "next": Future, "soon": Future, "later": Future, "someday": Future,
+}
+
+// DetectTense returns the tense of every token of a text.
+// The result is aligned with the output of `text.GenerateValidWords`.
+func DetectTense(textString string) []Tense {
+	return annotateText(textString).Tense
+}
+
+// auxiliaryTense returns the tense set by the token at position i, if it is an auxiliary verb.
+func auxiliaryTense(tokens []text.Token, infos []tokenInfo, i int) (Tense, bool) {
+	if infos[i].marks&willMark != 0 {
+		return Future, true
+	}
+	if tokens[i].Word == "going" && i+1 < len(tokens) && tokens[i+1].Word == "to" {
+		return Future, true
+	}
+	return infos[i].auxiliary, infos[i].roles&auxiliaryRole != 0
+}
+
+// hasWillContraction checks if a raw token contains the contraction of "will", such as "I'll" or "we’ll".
+func hasWillContraction(raw string) bool {
+	for _, apostrophe := range []string{"'", "’"} {
+		i := strings.Index(raw, apostrophe)
+		if i < 0 {
+			continue
+		}
+		rest := raw[i+len(apostrophe):]
+		if len(rest) >= 2 && (rest[0] == 'l' || rest[0] == 'L') && (rest[1] == 'l' || rest[1] == 'L') {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package sentiment
+
+import (
+	"testing"
+)
+
+func TestDetectTense(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   []Tense
+	}
+	cases := []testCase{
+		{textString: "I am happy", expected: []Tense{Present, Present, Present}},
+		{textString: "I was so sad last year", expected: []Tense{Past, Past, Past, Past, Past, Past}},
+		{textString: "so sad last year", expected: []Tense{Past, Past, Past, Past}},
+		{textString: "I'll be nervous tomorrow", expected: []Tense{Future, Future, Future, Future}},
+		{textString: "I am going to be calm", expected: []Tense{Present, Present, Future, Future, Future, Future}},
+		{textString: "I'm sad about yesterday", expected: []Tense{Present, Present, Present, Present}},
+		{textString: "I was tired, now I am calm", expected: []Tense{Present, Past, Past, Present, Present, Present, Present}}}
+
+	for _, c := range cases {
+		out := DetectTense(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for i := range out {
+			if out[i] != c.expected[i] {
+				t.Errorf("Failed: expected %v, recieved %v for %q", c.expected, out, c.textString)
+				break
+			}
+		}
+	}
+}
+
+func TestAnalyzerPresentOnly(t *testing.T) {
+	type testCase struct {
+		analyzer   Analyzer
+		textString string
+		expected   []Category
+	}
+	cases := []testCase{
+		{analyzer: Analyzer{}, textString: "I was sad last year, I am happy", expected: []Category{"sadness", "joviality"}},
+		{analyzer: Analyzer{PresentOnly: true}, textString: "I was sad last year, I am happy", expected: []Category{"joviality"}},
+		{analyzer: Analyzer{PresentOnly: true}, textString: "I will be nervous tomorrow", expected: []Category{}}}
+
+	for _, c := range cases {
+		out := c.analyzer.CategoryWeights(c.textString)
+		if len(out) != len(c.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			continue
+		}
+		for _, e := range c.expected {
+			if out[e] != 1 {
+				t.Errorf("Failed: expected %v, recieved %v", c.expected, out)
+			}
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Multi-topic classification. A `TopicSet` classifies a text into all its matching topics in one pass:
+the plain-word topics are matched together by a single matcher, the topic queries on the same words.
+`Analyzer.AggregateTopics` reports the sentiment of the texts of every topic, and of the texts of no topic,
+along with their deviations from the world baseline.
+*/
+
+// NoTopic is the name of the report of the texts that match no topic of a set.
+const NoTopic = "no-topic"
+
+// Topic is a named topic, which can be a topic query, see `ParseTopicQuery`.
+type Topic struct {
+	// Name is the canonical name of the topic. It defaults to its query.
+	Name string
+	// Query is the query of the topic. It defaults to its name, including for the topics of `ReadTopics`.
+	Query string
+	// Aliases are the other words and phrases of the topic, such as "coronavirus" and "the pandemic" for "covid".
+	// They are processed like the words of the texts, and matched exactly: "#COVID19" is the word "covid19".
+	Aliases []string
+}
+
+// TopicSet is a compiled set of topics. Create it with `NewTopicSet`.
+type TopicSet struct {
+	topics []Topic
+	// matcher matches the plain-word topics, whose indexes are mapped by their query in plain.
+	matcher *Matcher
+	plain   map[string][]int
+	// queries are the compiled queries of the topics, nil for the plain-word ones.
+	queries []*TopicQuery
+	// aliases are the compiled aliases of the topics, nil for the topics with no alias.
+	aliases []queryNode
+}
+
+// NewTopicSet compiles a set of topics. The names of the topics must be unique, and differ from `NoTopic`.
+func NewTopicSet(topics ...Topic) (*TopicSet, error) {
+	s := &TopicSet{plain: map[string][]int{}}
+	names := map[string]bool{}
+	words := []string{}
+	for i, t := range topics {
+		if t.Name == "" {
+			t.Name = t.Query
+		}
+		if t.Query == "" {
+			t.Query = t.Name
+		}
+		switch {
+		case t.Name == NoTopic:
+			return nil, fmt.Errorf("topic name %q is reserved", t.Name)
+		case names[t.Name]:
+			return nil, fmt.Errorf("duplicate topic %q", t.Name)
+		}
+		names[t.Name] = true
+		q, err := ParseTopicQuery(t.Query)
+		if err != nil {
+			return nil, fmt.Errorf("topic %q: %v", t.Name, err)
+		}
+		if q.plainWord() {
+			if len(s.plain[t.Query]) == 0 {
+				words = append(words, t.Query)
+			}
+			s.plain[t.Query] = append(s.plain[t.Query], i)
+			q = nil
+		}
+		aliases, err := compileAliases(t.Aliases)
+		if err != nil {
+			return nil, fmt.Errorf("topic %q: %v", t.Name, err)
+		}
+		s.topics = append(s.topics, t)
+		s.queries = append(s.queries, q)
+		s.aliases = append(s.aliases, aliases)
+	}
+	s.matcher = newMatcher(nil, words)
+	return s, nil
+}
+
+// Names returns the names of the topics, in the order of the set.
+func (s *TopicSet) Names() []string {
+	res := []string{}
+	for _, t := range s.topics {
+		res = append(res, t.Name)
+	}
+	return res
+}
+
+// Match returns the names of the topics of the text, in the order of the set.
+func (s *TopicSet) Match(textString string) []string {
+	var sc Scanner
+	res := []string{}
+	for _, i := range s.appendMatches(nil, sc.queryContext(sc.Analyze(textString))) {
+		res = append(res, s.topics[i].Name)
+	}
+	return res
+}
+
+// appendMatches appends to dst the sorted indexes of the topics of the text.
+func (s *TopicSet) appendMatches(dst []int, c *queryContext) []int {
+	found := make([]bool, len(s.topics))
+	for _, h := range s.matcher.Hits(c.words) {
+		if h.Kind == TopicHit {
+			for _, i := range s.plain[h.Pattern] {
+				found[i] = true
+			}
+		}
+	}
+	for i, q := range s.queries {
+		if !found[i] && ((q != nil && q.matches(c)) || (s.aliases[i] != nil && s.aliases[i].matches(c))) {
+			found[i] = true
+		}
+	}
+	for i, f := range found {
+		if f {
+			dst = append(dst, i)
+		}
+	}
+	return dst
+}
+
+// Rule returns the rule satisfied by the analyses that match the named topic, or one of its aliases.
+func (s *TopicSet) Rule(name string) (Rule, error) {
+	for i, t := range s.topics {
+		if t.Name == name {
+			return func(an Analyzer, a Analysis) bool {
+				for _, j := range s.appendMatches(nil, &queryContext{words: a.Words, text: a.Text}) {
+					if j == i {
+						return true
+					}
+				}
+				return false
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown topic %q", name)
+}
+
+// TopicReport is the sentiment of the texts of a topic.
+type TopicReport struct {
+	// Topic is the name of the topic, `NoTopic` for the texts that match no topic.
+	Topic string
+	// Texts is the number of texts of the topic, of which Valid are valid to be considered for sentiment analysis.
+	Texts int
+	Valid int
+	// Categories is the aggregate sentiment value of every category found in the valid texts, see `AggregateCategories`.
+	Categories map[Category]float64
+	// Deviations are the relative deviations of the categories from the world baseline, see `Deviations`.
+	// They are empty if the topic has no valid text.
+	Deviations map[Category]float64
+}
+
+// AggregateTopics returns the sentiment report of every topic of the set, see `Analyzer.AggregateTopics`.
+func AggregateTopics(texts []string, topics *TopicSet) ([]TopicReport, error) {
+	return Analyzer{}.AggregateTopics(texts, topics)
+}
+
+// AggregateTopics returns the sentiment report of every topic of the set, in the order of the set, followed by
+// the report of the texts that match no topic. Every text is analyzed once, and counted in all its topics.
+// Unlike `AggregateCategories`, the texts are validated, and the categories are aggregated over the valid texts.
+func (an Analyzer) AggregateTopics(texts []string, topics *TopicSet) ([]TopicReport, error) {
+	if len(texts) == 0 {
+		return nil, fmt.Errorf("texts is empty")
+	}
+	sc := getScanner(an)
+	defer putScanner(sc)
+	res := make([]TopicReport, len(topics.topics)+1)
+	for i, t := range topics.topics {
+		res[i] = TopicReport{Topic: t.Name, Categories: map[Category]float64{}}
+	}
+	res[len(topics.topics)] = TopicReport{Topic: NoTopic, Categories: map[Category]float64{}}
+	matches := []int{}
+	weights := map[Category]float64{}
+	for _, t := range texts {
+		a := sc.Analyze(t)
+		matches = topics.appendMatches(matches[:0], sc.queryContext(a))
+		if len(matches) == 0 {
+			matches = append(matches, len(topics.topics))
+		}
+		valid := an.valid(*a)
+		if valid {
+			for c := range weights {
+				delete(weights, c)
+			}
+			sc.counted = an.appendCountedStates(sc.counted[:0], *a)
+			sc.resolver.addTo(weights, sc.counted, an)
+		}
+		for _, i := range matches {
+			res[i].Texts++
+			if !valid {
+				continue
+			}
+			res[i].Valid++
+			for c, w := range weights {
+				res[i].Categories[c] += w
+			}
+		}
+	}
+	for i := range res {
+		for c, s := range res[i].Categories {
+			res[i].Categories[c] = s / float64(res[i].Valid)
+		}
+		res[i].Deviations = map[Category]float64{}
+		if res[i].Valid > 0 {
+			res[i].Deviations = Deviations(res[i].Categories, WorldBaseline)
+		}
+	}
+	return res, nil
+}
+
+// Deviations returns the relative deviation, (value - baseline) / baseline, of the aggregate sentiment value
+// of every category of the baseline, such as `WorldBaseline`. The categories missing from the values have
+// a value of 0, and a deviation of -1.
+func Deviations(values map[Category]float64, baseline map[Category]float64) map[Category]float64 {
+	res := map[Category]float64{}
+	for c, b := range baseline {
+		if b != 0 {
+			res[c] = (values[c] - b) / b
+		}
+	}
+	return res
+}
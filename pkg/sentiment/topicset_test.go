@@ -0,0 +1,85 @@
+package sentiment
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTopicSetMatch(t *testing.T) {
+	set, err := NewTopicSet(Topic{Name: "pandemic", Query: "corona OR covid"}, Topic{Query: "vaccine"},
+		Topic{Name: "climate", Query: `"climate change"`}, Topic{Name: "virus", Query: "covid"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := set.Names(); !reflect.DeepEqual(out, []string{"pandemic", "vaccine", "climate", "virus"}) {
+		t.Errorf("Failed: expected %v, recieved %v", []string{"pandemic", "vaccine", "climate", "virus"}, out)
+	}
+	type testCase struct {
+		textString string
+		expected   []string
+	}
+	testCases := []testCase{
+		{textString: "I am happy", expected: []string{}},
+		{textString: "the covid vaccine", expected: []string{"pandemic", "vaccine", "virus"}},
+		{textString: "Corona and climate change", expected: []string{"pandemic", "climate"}}}
+	for _, tc := range testCases {
+		if out := set.Match(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v for %q", tc.expected, out, tc.textString)
+		}
+	}
+}
+
+func TestNewTopicSetErrors(t *testing.T) {
+	for _, topics := range [][]Topic{
+		{{Query: "covid"}, {Query: "covid"}},
+		{{Name: NoTopic, Query: "covid"}},
+		{{Name: "broken", Query: "(covid"}}} {
+		if _, err := NewTopicSet(topics...); err == nil {
+			t.Errorf("Failed: expected an error for %v", topics)
+		}
+	}
+}
+
+func TestAggregateTopics(t *testing.T) {
+	set, err := NewTopicSet(Topic{Name: "pandemic", Query: "corona OR covid"}, Topic{Query: "vaccine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	texts := []string{"I am happy about the vaccine", "I am sad about covid", "I am happy about the covid vaccine",
+		"covid xyz", "I am tired"}
+	out, err := AggregateTopics(texts, set)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []TopicReport{
+		{Topic: "pandemic", Texts: 3, Valid: 2, Categories: map[Category]float64{"sadness": 0.5, "joviality": 0.5}},
+		{Topic: "vaccine", Texts: 2, Valid: 2, Categories: map[Category]float64{"joviality": 1}},
+		{Topic: NoTopic, Texts: 1, Valid: 1, Categories: map[Category]float64{"fatigue": 1}}}
+	if len(out) != len(expected) {
+		t.Fatalf("Failed: expected %v, recieved %v", expected, out)
+	}
+	for i, r := range out {
+		e := expected[i]
+		if r.Topic != e.Topic || r.Texts != e.Texts || r.Valid != e.Valid || !reflect.DeepEqual(r.Categories, e.Categories) {
+			t.Errorf("Failed: expected %v, recieved %v", e, r)
+		}
+		if d := r.Deviations["joviality"]; math.Abs(d-(r.Categories["joviality"]-WorldBaseline["joviality"])/WorldBaseline["joviality"]) > 1e-9 {
+			t.Errorf("Failed: expected the deviation of %v from the baseline, recieved %v", r.Categories["joviality"], d)
+		}
+		if len(r.Deviations) != len(CategoriesMap) {
+			t.Errorf("Failed: expected %v deviations, recieved %v", len(CategoriesMap), r.Deviations)
+		}
+	}
+	if _, err := AggregateTopics([]string{}, set); err == nil {
+		t.Errorf("Failed: expected an error for no texts")
+	}
+}
+
+func TestDeviations(t *testing.T) {
+	out := Deviations(map[Category]float64{"fear": 0.2}, map[Category]float64{"fear": 0.1, "sadness": 0.2, "shyness": 0})
+	expected := map[Category]float64{"fear": 1, "sadness": -1}
+	if !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v", expected, out)
+	}
+}
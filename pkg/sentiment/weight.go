@@ -0,0 +1,85 @@
+package sentiment
+
+import (
+	"fmt"
+)
+
+/*
+Weighted lexicon entries. Every state counts with weight 1 unless its entry has a `StateC.Weight`,
+so that the intense or prototypical states of a category ("furious") can outweigh the mild ones
+("irritable"). The entries can also be rated on the valence and arousal dimensions of affect.
+*/
+
+// Dimensions are the valence and the arousal of a state.
+type Dimensions struct {
+	// Valence ranges from -1 (unpleasant) to 1 (pleasant).
+	Valence float64
+	// Arousal ranges from 0 (calm) to 1 (excited).
+	Arousal float64
+}
+
+// weight returns the weight of the state, 1 if it has none.
+func (sc StateC) weight() float64 {
+	if sc.Weight == 0 {
+		return 1
+	}
+	return sc.Weight
+}
+
+// checkWeights returns an error if the weight or the dimensions of the entry are out of range.
+// A weight of 0 is no weight, so that it weighs 1: the weights range from 0, excluded, to 1.
+func (sc StateC) checkWeights() error {
+	if !(sc.Weight >= 0 && sc.Weight <= 1) {
+		return fmt.Errorf("weight %v out of range (0, 1]", sc.Weight)
+	}
+	if d := sc.Dimensions; d != nil {
+		if !(d.Valence >= -1 && d.Valence <= 1) {
+			return fmt.Errorf("valence %v out of range [-1, 1]", d.Valence)
+		}
+		if !(d.Arousal >= 0 && d.Arousal <= 1) {
+			return fmt.Errorf("arousal %v out of range [0, 1]", d.Arousal)
+		}
+	}
+	return nil
+}
+
+// CategoryScores determines the sentiment categories of a text, resolved with the analyzer's strategy,
+// along with their weighted score: the weight with which the text counts in each of them, scaled by the
+// sum of the weights of its distinct states, so that a score can exceed 1. It is `CategoryWeights`
+// of the weighted analyzer that sums the weights, see `Analyzer.SumWeights`.
+func (an Analyzer) CategoryScores(textString string) map[Category]float64 {
+	an.Weighted, an.SumWeights = true, true
+	return an.CategoryWeights(textString)
+}
+
+// TextDimensions returns the valence and the arousal of a text, see `Analyzer.Dimensions`.
+func TextDimensions(textString string) (Dimensions, bool) {
+	return Analyzer{}.Dimensions(textString)
+}
+
+// Dimensions returns the valence and the arousal of a text: the means of the ones of its distinct counted states,
+// weighted by their weights. It returns false if none of its counted states is rated.
+func (an Analyzer) Dimensions(textString string) (Dimensions, bool) {
+	sc := getScanner(an)
+	defer putScanner(sc)
+	lexicon := an.lexicon().Categories
+	res, total := Dimensions{}, 0.0
+	seen := map[string]bool{}
+	for _, m := range sc.Analyze(textString).States {
+		e := lexicon[m.State]
+		if seen[m.State] || e.Dimensions == nil || !an.countsState(m) {
+			continue
+		}
+		seen[m.State] = true
+		w := e.weight()
+		res.Valence += w * e.Dimensions.Valence
+		res.Arousal += w * e.Dimensions.Arousal
+		total += w
+	}
+	if total == 0 {
+		return Dimensions{}, false
+	}
+	res.Valence /= total
+	res.Arousal /= total
+	return res, true
+}
@@ -0,0 +1,113 @@
+package sentiment
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+var weightedLexicon = mustLexicon("weighted", []string{"furious", "irritable", "calm"}, map[string]StateC{
+	"furious":   {Category: Hostility, Direction: Negative, Weight: 1, Dimensions: &Dimensions{Valence: -0.8, Arousal: 0.9}},
+	"irritable": {Category: Hostility, Direction: Negative, Weight: 0.5, Dimensions: &Dimensions{Valence: -0.5, Arousal: 0.6}},
+	"calm":      {Category: Serenity, Direction: Other}}, LexiconOptions{})
+
+func TestCategoryScores(t *testing.T) {
+	type testCase struct {
+		textString string
+		expected   map[Category]float64
+	}
+	testCases := []testCase{
+		{textString: "I am xyz", expected: map[Category]float64{}},
+		{textString: "I am irritable", expected: map[Category]float64{"hostility": 0.5}},
+		{textString: "I am furious", expected: map[Category]float64{"hostility": 1}},
+		{textString: "I am furious and irritable", expected: map[Category]float64{"hostility": 1.5}},
+		{textString: "I am irritable, so irritable", expected: map[Category]float64{"hostility": 0.5}},
+		{textString: "I am irritable and calm", expected: map[Category]float64{"hostility": 0.5, "serenity": 1}}}
+	an := Analyzer{Lexicon: weightedLexicon}
+	for _, tc := range testCases {
+		if out := an.CategoryScores(tc.textString); !reflect.DeepEqual(out, tc.expected) {
+			t.Errorf("Failed: expected %v, recieved %v", tc.expected, out)
+		}
+	}
+	if out := an.CategoryWeights("I am irritable"); out["hostility"] != 1 {
+		t.Errorf("Failed: expected %v, recieved %v", map[Category]float64{"hostility": 1}, out)
+	}
+	an.Weighted = true
+	if out := an.CategoryWeights("I am furious and irritable"); out["hostility"] != 1 {
+		t.Errorf("Failed: expected %v, recieved %v", map[Category]float64{"hostility": 1}, out)
+	}
+}
+
+func TestWeightedAggregates(t *testing.T) {
+	an := Analyzer{Lexicon: weightedLexicon, Weighted: true}
+	texts := []string{"I am irritable", "I am furious", "I am xyz", "I am calm"}
+	expected := map[Category]float64{"hostility": 0.375, "serenity": 0.25}
+	if out, err := an.AggregateCategories(texts); err != nil || !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+	an.Strategy = Fractional
+	expectedRollup := Rollup{Texts: 1, Overall: 0.75,
+		Directions: map[Direction]float64{Negative: 0.25, Other: 0.5},
+		Categories: map[Category]float64{Hostility: 0.25, Serenity: 0.5},
+		States:     map[string]float64{"irritable": 0.25, "calm": 0.5}}
+	if out := an.Rollup("I am irritable and calm"); !reflect.DeepEqual(out, expectedRollup) {
+		t.Errorf("Failed: expected %v, recieved %v", expectedRollup, out)
+	}
+	an.SumWeights = true
+	expectedRollup = Rollup{Texts: 1, Overall: 1,
+		Directions: map[Direction]float64{Negative: 0.75, Other: 0.5},
+		Categories: map[Category]float64{Hostility: 0.75, Serenity: 0.5},
+		States:     map[string]float64{"furious": 0.5, "irritable": 0.25, "calm": 0.5}}
+	if out := an.Rollup("I am furious and irritable and calm"); !reflect.DeepEqual(out, expectedRollup) {
+		t.Errorf("Failed: expected %v, recieved %v", expectedRollup, out)
+	}
+	an.Strategy = CountAll
+	expected = map[Category]float64{"hostility": 0.75, "serenity": 0.5}
+	if out, err := an.AggregateCategories([]string{"I am furious and irritable and calm", "I am xyz"}); err != nil || !reflect.DeepEqual(out, expected) {
+		t.Errorf("Failed: expected %v, recieved %v, %v", expected, out, err)
+	}
+}
+
+func TestDimensions(t *testing.T) {
+	an := Analyzer{Lexicon: weightedLexicon}
+	out, ok := an.Dimensions("I am furious and irritable, so irritable")
+	if !ok || math.Abs(out.Valence+0.7) > 1e-9 || math.Abs(out.Arousal-0.8) > 1e-9 {
+		t.Errorf("Failed: expected %v, recieved %v, %v", Dimensions{Valence: -0.7, Arousal: 0.8}, out, ok)
+	}
+	if out, ok := an.Dimensions("I am calm"); ok {
+		t.Errorf("Failed: expected no dimensions, recieved %v", out)
+	}
+	if out, ok := TextDimensions("I am happy"); ok {
+		t.Errorf("Failed: expected no dimensions, recieved %v", out)
+	}
+}
+
+func TestLexiconWeights(t *testing.T) {
+	for _, sc := range []StateC{
+		{Category: Hostility, Direction: Negative, Weight: 1.5},
+		{Category: Hostility, Direction: Negative, Weight: -0.1},
+		{Category: Hostility, Direction: Negative, Weight: math.NaN()},
+		{Category: Hostility, Direction: Negative, Dimensions: &Dimensions{Valence: math.NaN()}},
+		{Category: Hostility, Direction: Negative, Dimensions: &Dimensions{Valence: -2}},
+		{Category: Hostility, Direction: Negative, Dimensions: &Dimensions{Arousal: -1}}} {
+		if _, err := NewLexicon("weights", []string{"furious"}, map[string]StateC{"furious": sc}); err == nil {
+			t.Errorf("Failed: expected an error for %v", sc)
+		}
+	}
+}
+
+func TestStateCWeight(t *testing.T) {
+	type testCase struct {
+		entry    StateC
+		expected float64
+	}
+	testCases := []testCase{
+		{entry: StateC{}, expected: 1},
+		{entry: StateC{Weight: 0.5}, expected: 0.5},
+		{entry: StateC{Weight: 1}, expected: 1}}
+	for _, tc := range testCases {
+		if out := tc.entry.weight(); out != tc.expected {
+			t.Errorf("Failed: expected %v, recieved %v", tc.expected, out)
+		}
+	}
+}